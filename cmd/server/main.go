@@ -1,13 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"text/tabwriter"
+	"time"
 
+	"cgoffline/internal/domain"
+	"cgoffline/internal/handler"
 	"cgoffline/internal/repository"
+	"cgoffline/internal/scheduler"
 	"cgoffline/internal/service"
 	"cgoffline/migrations"
 	"cgoffline/pkg/config"
@@ -19,10 +29,34 @@ func main() {
 	var (
 		syncPlatforms  = flag.Bool("sync-platforms", false, "Only sync asset platforms and exit")
 		syncCategories = flag.Bool("sync-categories", false, "Only sync coin categories and exit")
+		syncCatMarket  = flag.Bool("sync-categories-market", false, "Sync coin category market data and append category snapshots and exit")
 		syncExchanges  = flag.Bool("sync-exchanges", false, "Only sync exchanges and exit")
+		syncExchData   = flag.Bool("sync-exchanges-data", false, "Sync exchange details, tickers and volume charts (filtered by trust score and volume) and exit")
 		syncCoins      = flag.Bool("sync-coins", false, "Only sync coins and their market data and exit")
+		syncCoinList   = flag.Bool("sync-coin-list", false, "Sync every listed coin id, including inactive coins, and exit")
 		syncCoinsData  = flag.Bool("sync-coins-data", false, "Sync full coin data and tickers (filtered by volume) and exit")
+		syncOHLC       = flag.Bool("sync-ohlc", false, "Sync OHLC candles (filtered by volume) and exit")
+		syncNFTs       = flag.Bool("sync-nfts", false, "Only sync the NFT collection list and exit")
+		syncNFTsData   = flag.Bool("sync-nfts-data", false, "Sync NFT collection details and market snapshots (filtered by market cap) and exit")
+		syncDerivs     = flag.Bool("sync-derivatives", false, "Sync derivative contracts and append funding rate snapshots and exit")
+		syncDerivExch  = flag.Bool("sync-derivatives-exchanges", false, "Only sync derivatives exchanges and exit")
+		syncExchRates  = flag.Bool("sync-exchange-rates", false, "Capture a snapshot of the BTC exchange rates and exit")
+		syncVsCurr     = flag.Bool("sync-vs-currencies", false, "Only sync the supported vs currencies and exit")
+		syncGlobal     = flag.Bool("sync-global", false, "Capture a global market and DeFi snapshot and exit")
+		globalHistory  = flag.Bool("global-history", false, "Show recent global market snapshots and exit")
+		globalDays     = flag.Int("global-history-days", 7, "Number of days shown by -global-history")
+		syncTrending   = flag.Bool("sync-trending", false, "Capture the trending lists (and top gainers/losers on the pro plan) and exit")
+		search         = flag.String("search", "", "Search CoinGecko for coins, exchanges, categories and NFTs matching the query and exit")
 		syncAll        = flag.Bool("sync-all", false, "Sync asset platforms, coin categories, exchanges, and coins and exit")
+		backfill       = flag.Bool("backfill-history", false, "Backfill historical market charts (filtered by volume) and exit")
+		backfillFrom   = flag.String("backfill-from", "", "Backfill start date, YYYY-MM-DD (default: 365 days before -backfill-to)")
+		backfillTo     = flag.String("backfill-to", "", "Backfill end date (exclusive), YYYY-MM-DD (default: today)")
+		backfillGran   = flag.String("backfill-granularity", domain.ChartGranularityDaily, "Backfill granularity: daily or hourly")
+		syncHistory    = flag.Bool("sync-history", false, "Show recent sync runs and exit")
+		historyLimit   = flag.Int("sync-history-limit", 20, "Number of runs shown by -sync-history")
+		historyKind    = flag.String("sync-history-kind", "", "Only show runs of this kind with -sync-history")
+		resolveContr   = flag.String("resolve-contract", "", "Resolve PLATFORM/ADDRESS contract addresses (comma-separated) to coins and exit")
+		daemon         = flag.Bool("daemon", false, "Run scheduled syncs and serve the HTTP API until stopped")
 		migrate        = flag.Bool("migrate", false, "Run database migrations and exit")
 		rollback       = flag.Bool("rollback", false, "Rollback last migration and exit")
 		status         = flag.Bool("status", false, "Show migration status and exit")
@@ -36,8 +70,16 @@ func main() {
 	logger.InitLogger(cfg.Logging)
 	log := logger.GetLogger()
 
+	if err := cfg.Validate(); err != nil {
+		log.WithError(err).Fatal("Invalid configuration")
+	}
+
 	log.Info("Starting cgoffline application")
 
+	// Cancel in-flight work on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	// Connect to database
 	db, err := repository.NewDatabase(cfg.Database)
 	if err != nil {
@@ -73,6 +115,32 @@ func main() {
 		return
 	}
 
+	syncJournal := service.NewSyncJournal(repository.NewSyncRunRepository(db))
+	coinGeckoClient := service.NewCoinGeckoClient(cfg.API)
+	vsCurrencyService := service.NewVsCurrencyService(repository.NewSupportedVsCurrencyRepository(db), coinGeckoClient, syncJournal)
+
+	// VS_CURRENCIES can only be checked against CoinGecko's list, which lives in the database, so only the
+	// migration commands above run before it
+	if err := vsCurrencyService.ValidateVsCurrencies(ctx, cfg.API.VsCurrencies); err != nil {
+		log.WithError(err).Fatal("Invalid configuration")
+	}
+
+	// Handle sync-history mode
+	if *syncHistory {
+		if err := printSyncHistory(repository.NewSyncRunRepository(db), *historyKind, *historyLimit); err != nil {
+			log.WithError(err).Fatal("Failed to show sync history")
+		}
+		return
+	}
+
+	// Handle global-history mode
+	if *globalHistory {
+		if err := printGlobalHistory(repository.NewGlobalSnapshotRepository(db), *globalDays); err != nil {
+			log.WithError(err).Fatal("Failed to show global market history")
+		}
+		return
+	}
+
 	// Initialize repositories and services
 	assetPlatformRepo := repository.NewAssetPlatformRepository(db)
 	coinCategoryRepo := repository.NewCoinCategoryRepository(db)
@@ -81,16 +149,127 @@ func main() {
 	coinMarketDataRepo := repository.NewCoinMarketDataRepository(db)
 	coinDetailRepo := repository.NewCoinDetailRepository(db)
 	coinTickerRepo := repository.NewCoinTickerRepository(db)
-	coinGeckoClient := service.NewCoinGeckoClient(cfg.API)
-	assetPlatformService := service.NewAssetPlatformService(assetPlatformRepo, coinGeckoClient)
-	coinCategoryService := service.NewCoinCategoryService(coinCategoryRepo, coinGeckoClient)
-	exchangeService := service.NewExchangeService(exchangeRepo, coinGeckoClient)
-	coinService := service.NewCoinService(coinRepo, coinMarketDataRepo, exchangeRepo, coinDetailRepo, coinTickerRepo, coinGeckoClient)
+	coinMarketSnapshotRepo := repository.NewCoinMarketSnapshotRepository(db)
+	coinQuoteRepo := repository.NewCoinQuoteRepository(db)
+	coinCategoryMembershipRepo := repository.NewCoinCategoryMembershipRepository(db)
+	coinContractRepo := repository.NewCoinContractRepository(db)
+	coinMarketChartRepo := repository.NewCoinMarketChartRepository(db)
+	coinOHLCRepo := repository.NewCoinOHLCRepository(db)
+	exchangeDetailRepo := repository.NewExchangeDetailRepository(db)
+	exchangeVolumeRepo := repository.NewExchangeVolumeRepository(db)
+	globalSnapshotRepo := repository.NewGlobalSnapshotRepository(db)
+	trendingRepo := repository.NewTrendingRepository(db)
+	topMoverRepo := repository.NewTopMoverRepository(db)
+	nftCollectionRepo := repository.NewNFTCollectionRepository(db)
+	nftSnapshotRepo := repository.NewNFTSnapshotRepository(db)
+	derivativesExchangeRepo := repository.NewDerivativesExchangeRepository(db)
+	derivativeContractRepo := repository.NewDerivativeContractRepository(db)
+	derivativeSnapshotRepo := repository.NewDerivativeSnapshotRepository(db)
+	exchangeRateRepo := repository.NewExchangeRateRepository(db)
+	categorySnapshotRepo := repository.NewCategorySnapshotRepository(db)
+	assetPlatformService := service.NewAssetPlatformService(assetPlatformRepo, coinGeckoClient, syncJournal)
+	coinCategoryService := service.NewCoinCategoryService(coinCategoryRepo, categorySnapshotRepo, coinGeckoClient, syncJournal)
+	exchangeService := service.NewExchangeService(exchangeRepo, exchangeDetailRepo, coinMarketDataRepo, exchangeVolumeRepo, coinRepo, coinGeckoClient, syncJournal)
+	coinService := service.NewCoinService(coinRepo, coinMarketDataRepo, exchangeRepo, coinDetailRepo, coinTickerRepo, coinMarketSnapshotRepo, coinQuoteRepo, coinCategoryMembershipRepo, coinContractRepo, repository.NewSyncCheckpointRepository(db), coinGeckoClient, syncJournal)
+	coinHistoryService := service.NewCoinHistoryService(coinRepo, coinMarketChartRepo, coinGeckoClient, syncJournal)
+	coinOHLCService := service.NewCoinOHLCService(coinRepo, coinOHLCRepo, coinGeckoClient, syncJournal)
+	globalService := service.NewGlobalService(globalSnapshotRepo, coinGeckoClient, syncJournal)
+	trendingService := service.NewTrendingService(trendingRepo, topMoverRepo, coinGeckoClient, syncJournal)
+	nftService := service.NewNFTService(nftCollectionRepo, nftSnapshotRepo, coinGeckoClient, syncJournal, cfg.API.ContractLookupOnline)
+	derivativesService := service.NewDerivativesService(derivativesExchangeRepo, derivativeContractRepo, derivativeSnapshotRepo, coinGeckoClient, syncJournal)
+	exchangeRateService := service.NewExchangeRateService(exchangeRateRepo, coinGeckoClient, syncJournal)
+	contractService := service.NewContractService(coinRepo, coinContractRepo, coinDetailRepo, repository.NewContractLookupRepository(db), coinGeckoClient, service.ContractOptions{
+		Online:  cfg.API.ContractLookupOnline,
+		MissTTL: cfg.API.ContractLookupMissTTL,
+	})
+	coinsOptions := service.CoinsOptions{VsCurrencies: cfg.API.VsCurrencies}
+	ohlcOptions := service.OHLCOptions{
+		Interval:       cfg.API.OHLCInterval,
+		VsCurrency:     service.DefaultVsCurrency,
+		MinTotalVolume: cfg.API.MinTotalVolume,
+		Workers:        cfg.API.SyncWorkers,
+	}
+	coinsDataOptions := service.CoinsDataOptions{
+		MinTotalVolume: cfg.API.MinTotalVolume,
+		Freshness:      cfg.API.CoinsDataFreshness,
+		MaxDuration:    cfg.API.CoinsDataMaxDuration,
+		Workers:        cfg.API.SyncWorkers,
+	}
+	exchangesDataOptions := service.ExchangesDataOptions{
+		MinTrustScore:     cfg.API.ExchangesMinTrustScore,
+		MinTradeVolumeBTC: cfg.API.ExchangesMinVolumeBTC,
+		Workers:           cfg.API.SyncWorkers,
+	}
+	nftsDataOptions := service.NFTsDataOptions{
+		MinMarketCapUSD: cfg.API.NFTsMinMarketCapUSD,
+		Workers:         cfg.API.SyncWorkers,
+	}
+	// /coins/top_gainers_losers is only served on the pro plan
+	trendingOptions := service.TrendingOptions{
+		TopMovers:  cfg.API.Plan == config.PlanPro,
+		VsCurrency: service.DefaultVsCurrency,
+	}
+
+	// Handle resolve-contract mode
+	if *resolveContr != "" {
+		if err := printContractResolutions(ctx, contractService, *resolveContr); err != nil {
+			log.WithError(err).Fatal("Failed to resolve contracts")
+		}
+		return
+	}
+
+	// Handle search mode
+	if *search != "" {
+		if err := printSearchResults(ctx, trendingService, *search); err != nil {
+			log.WithError(err).Fatal("Failed to search CoinGecko")
+		}
+		return
+	}
+
+	// Handle sync-vs-currencies mode
+	if *syncVsCurr {
+		log.Info("Running supported vs currencies synchronization")
+		if err := vsCurrencyService.SyncSupportedVsCurrencies(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync supported vs currencies")
+		}
+		log.Info("Supported vs currencies synchronization completed successfully")
+		return
+	}
+
+	// Handle sync-exchange-rates mode
+	if *syncExchRates {
+		log.Info("Running exchange rates synchronization")
+		if err := exchangeRateService.SyncExchangeRates(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync exchange rates")
+		}
+		log.Info("Exchange rates synchronization completed successfully")
+		return
+	}
+
+	// Handle sync-global mode
+	if *syncGlobal {
+		log.Info("Running global market data synchronization")
+		if err := globalService.SyncGlobal(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync global market data")
+		}
+		log.Info("Global market data synchronization completed successfully")
+		return
+	}
+
+	// Handle sync-trending mode
+	if *syncTrending {
+		log.Info("Running trending synchronization")
+		if err := trendingService.SyncTrending(ctx, trendingOptions); err != nil {
+			log.WithError(err).Fatal("Failed to sync trending lists")
+		}
+		log.Info("Trending synchronization completed successfully")
+		return
+	}
 
 	// Handle sync-platforms mode
 	if *syncPlatforms {
 		log.Info("Running asset platforms synchronization")
-		if err := assetPlatformService.SyncAssetPlatforms(); err != nil {
+		if err := assetPlatformService.SyncAssetPlatforms(ctx); err != nil {
 			log.WithError(err).Fatal("Failed to sync asset platforms")
 		}
 		log.Info("Asset platforms synchronization completed successfully")
@@ -100,98 +279,465 @@ func main() {
 	// Handle sync-categories mode
 	if *syncCategories {
 		log.Info("Running coin categories synchronization")
-		if err := coinCategoryService.SyncCoinCategories(); err != nil {
+		if err := coinCategoryService.SyncCoinCategories(ctx); err != nil {
 			log.WithError(err).Fatal("Failed to sync coin categories")
 		}
 		log.Info("Coin categories synchronization completed successfully")
 		return
 	}
 
+	// Handle sync-categories-market mode
+	if *syncCatMarket {
+		log.Info("Running coin categories market data synchronization")
+		if err := coinCategoryService.SyncCoinCategoriesMarket(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync coin categories market data")
+		}
+		log.Info("Coin categories market data synchronization completed successfully")
+		return
+	}
+
 	// Handle sync-exchanges mode
 	if *syncExchanges {
 		log.Info("Running exchanges synchronization")
-		if err := exchangeService.SyncExchanges(); err != nil {
+		if err := exchangeService.SyncExchanges(ctx); err != nil {
 			log.WithError(err).Fatal("Failed to sync exchanges")
 		}
 		log.Info("Exchanges synchronization completed successfully")
 		return
 	}
 
+	// Handle sync-exchanges-data mode
+	if *syncExchData {
+		log.Info("Running exchanges data synchronization (details, tickers and volume charts)")
+		if err := exchangeService.SyncExchangesData(ctx, exchangesDataOptions); err != nil {
+			log.WithError(err).Fatal("Failed to sync exchanges data")
+		}
+		log.Info("Exchanges data synchronization completed successfully")
+		return
+	}
+
+	// Handle sync-nfts mode
+	if *syncNFTs {
+		log.Info("Running NFT collections synchronization")
+		if err := nftService.SyncNFTs(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync NFT collections")
+		}
+		log.Info("NFT collections synchronization completed successfully")
+		return
+	}
+
+	// Handle sync-nfts-data mode
+	if *syncNFTsData {
+		log.Info("Running NFT collections data synchronization (details and market snapshots)")
+		if err := nftService.SyncNFTsData(ctx, nftsDataOptions); err != nil {
+			log.WithError(err).Fatal("Failed to sync NFT collections data")
+		}
+		log.Info("NFT collections data synchronization completed successfully")
+		return
+	}
+
+	// Handle sync-derivatives-exchanges mode
+	if *syncDerivExch {
+		log.Info("Running derivatives exchanges synchronization")
+		if err := derivativesService.SyncDerivativesExchanges(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync derivatives exchanges")
+		}
+		log.Info("Derivatives exchanges synchronization completed successfully")
+		return
+	}
+
+	// Handle sync-derivatives mode
+	if *syncDerivs {
+		log.Info("Running derivatives synchronization (contracts and snapshots)")
+		if err := derivativesService.SyncDerivatives(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync derivatives")
+		}
+		log.Info("Derivatives synchronization completed successfully")
+		return
+	}
+
 	// Handle sync-coins mode
 	if *syncCoins {
 		log.Info("Running coins synchronization")
-		if err := coinService.SyncCoins(); err != nil {
+		if err := coinService.SyncCoins(ctx, coinsOptions); err != nil {
 			log.WithError(err).Fatal("Failed to sync coins")
 		}
 		log.Info("Coins synchronization completed successfully")
 		return
 	}
 
+	// Handle sync-coin-list mode
+	if *syncCoinList {
+		log.Info("Running coin list synchronization")
+		if err := coinService.SyncCoinList(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync coin list")
+		}
+		log.Info("Coin list synchronization completed successfully")
+		return
+	}
+
 	// Handle sync-coins-data mode
 	if *syncCoinsData {
 		log.Info("Running coins data synchronization (details and tickers)")
-		if err := coinService.SyncCoinsData(cfg.API.MinTotalVolume); err != nil {
+		if err := coinService.SyncCoinsData(ctx, coinsDataOptions); err != nil {
 			log.WithError(err).Fatal("Failed to sync coins data")
 		}
 		log.Info("Coins data synchronization completed successfully")
 		return
 	}
 
+	// Handle sync-ohlc mode
+	if *syncOHLC {
+		log.Info("Running OHLC synchronization")
+		if err := coinOHLCService.SyncOHLC(ctx, ohlcOptions); err != nil {
+			log.WithError(err).Fatal("Failed to sync OHLC")
+		}
+		log.Info("OHLC synchronization completed successfully")
+		return
+	}
+
+	// Handle backfill-history mode
+	if *backfill {
+		from, to, err := parseBackfillRange(*backfillFrom, *backfillTo)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid backfill range")
+		}
+		log.Info("Running market chart history backfill")
+		opts := service.BackfillOptions{
+			From:           from,
+			To:             to,
+			Granularity:    *backfillGran,
+			VsCurrency:     service.DefaultVsCurrency,
+			MinTotalVolume: cfg.API.MinTotalVolume,
+			Workers:        cfg.API.SyncWorkers,
+		}
+		if err := coinHistoryService.BackfillHistory(ctx, opts); err != nil {
+			log.WithError(err).Fatal("Failed to backfill market chart history")
+		}
+		log.Info("Market chart history backfill completed successfully")
+		return
+	}
+
 	// Handle sync-all mode
 	if *syncAll {
 		log.Info("Running full synchronization (platforms, categories, exchanges, and coins)")
 
 		// Sync asset platforms first
 		log.Info("Syncing asset platforms...")
-		if err := assetPlatformService.SyncAssetPlatforms(); err != nil {
+		if err := assetPlatformService.SyncAssetPlatforms(ctx); err != nil {
 			log.WithError(err).Fatal("Failed to sync asset platforms")
 		}
 		log.Info("Asset platforms synchronization completed successfully")
 
 		// Sync coin categories
 		log.Info("Syncing coin categories...")
-		if err := coinCategoryService.SyncCoinCategories(); err != nil {
+		if err := coinCategoryService.SyncCoinCategories(ctx); err != nil {
 			log.WithError(err).Fatal("Failed to sync coin categories")
 		}
 		log.Info("Coin categories synchronization completed successfully")
 
 		// Sync exchanges
 		log.Info("Syncing exchanges...")
-		if err := exchangeService.SyncExchanges(); err != nil {
+		if err := exchangeService.SyncExchanges(ctx); err != nil {
 			log.WithError(err).Fatal("Failed to sync exchanges")
 		}
 		log.Info("Exchanges synchronization completed successfully")
 
 		// Sync coins
 		log.Info("Syncing coins...")
-		if err := coinService.SyncCoins(); err != nil {
+		if err := coinService.SyncCoins(ctx, coinsOptions); err != nil {
 			log.WithError(err).Fatal("Failed to sync coins")
 		}
 		log.Info("Coins synchronization completed successfully")
 
+		// Sync the coin list, which adds coins without market data and reconciles delisted ones
+		log.Info("Syncing coin list...")
+		if err := coinService.SyncCoinList(ctx); err != nil {
+			log.WithError(err).Fatal("Failed to sync coin list")
+		}
+		log.Info("Coin list synchronization completed successfully")
+
 		log.Info("Full synchronization completed successfully")
 		return
 	}
 
+	// Build the read-only HTTP API
+	handlers := handler.Handlers{
+		Coin:          handler.NewCoinHandler(coinRepo, coinDetailRepo, coinMarketDataRepo, coinQuoteRepo, coinCategoryMembershipRepo, coinContractRepo),
+		Exchange:      handler.NewExchangeHandler(exchangeRepo, exchangeDetailRepo, coinMarketDataRepo, exchangeVolumeRepo),
+		CoinCategory:  handler.NewCoinCategoryHandler(coinCategoryRepo, coinCategoryMembershipRepo, categorySnapshotRepo),
+		AssetPlatform: handler.NewAssetPlatformHandler(assetPlatformRepo, coinContractRepo, nftCollectionRepo),
+		Contract:      handler.NewContractHandler(contractService),
+		Global:        handler.NewGlobalHandler(globalSnapshotRepo),
+		Trending:      handler.NewTrendingHandler(trendingRepo, topMoverRepo),
+		NFT:           handler.NewNFTHandler(nftCollectionRepo, nftSnapshotRepo, nftService),
+		Derivatives:   handler.NewDerivativesHandler(derivativesExchangeRepo, derivativeContractRepo, derivativeSnapshotRepo),
+		ExchangeRate:  handler.NewExchangeRateHandler(exchangeRateRepo, exchangeRateService),
+	}
+	if cfg.Server.CoinGeckoCompat {
+		handlers.CoinGecko = handler.NewCoinGeckoHandler(coinRepo, coinDetailRepo, coinTickerRepo, coinQuoteRepo, exchangeRepo, coinCategoryRepo, assetPlatformRepo)
+	}
+	server := handler.NewServer(cfg.Server, handler.NewRouter(handlers))
+
+	// Handle daemon mode
+	if *daemon {
+		log.Info("Running in daemon mode")
+
+		sched := scheduler.New()
+		jobs := []scheduler.Job{
+			{Name: "supported_vs_currencies", Schedule: cfg.Scheduler.VsCurrencies, Run: vsCurrencyService.SyncSupportedVsCurrencies},
+			{Name: "asset_platforms", Schedule: cfg.Scheduler.AssetPlatforms, Run: assetPlatformService.SyncAssetPlatforms},
+			{Name: "coin_categories", Schedule: cfg.Scheduler.CoinCategories, Run: coinCategoryService.SyncCoinCategories},
+			{Name: "coin_categories_market", Schedule: cfg.Scheduler.CoinCategoriesMarket, Run: coinCategoryService.SyncCoinCategoriesMarket},
+			{Name: "exchanges", Schedule: cfg.Scheduler.Exchanges, Run: exchangeService.SyncExchanges},
+			{Name: "exchanges_data", Schedule: cfg.Scheduler.ExchangesData, Run: func(ctx context.Context) error {
+				return exchangeService.SyncExchangesData(ctx, exchangesDataOptions)
+			}},
+			{Name: "nfts", Schedule: cfg.Scheduler.NFTs, Run: nftService.SyncNFTs},
+			{Name: "nfts_data", Schedule: cfg.Scheduler.NFTsData, Run: func(ctx context.Context) error {
+				return nftService.SyncNFTsData(ctx, nftsDataOptions)
+			}},
+			{Name: "derivatives_exchanges", Schedule: cfg.Scheduler.DerivativesExchanges, Run: derivativesService.SyncDerivativesExchanges},
+			{Name: "derivatives", Schedule: cfg.Scheduler.Derivatives, Run: derivativesService.SyncDerivatives},
+			{Name: "coins", Schedule: cfg.Scheduler.Coins, Run: func(ctx context.Context) error {
+				return coinService.SyncCoins(ctx, coinsOptions)
+			}},
+			{Name: "coin_list", Schedule: cfg.Scheduler.CoinList, Run: coinService.SyncCoinList},
+			{Name: "coins_data", Schedule: cfg.Scheduler.CoinsData, Run: func(ctx context.Context) error {
+				return coinService.SyncCoinsData(ctx, coinsDataOptions)
+			}},
+			{Name: "global", Schedule: cfg.Scheduler.Global, Run: globalService.SyncGlobal},
+			{Name: "exchange_rates", Schedule: cfg.Scheduler.ExchangeRates, Run: exchangeRateService.SyncExchangeRates},
+			{Name: "trending", Schedule: cfg.Scheduler.Trending, Run: func(ctx context.Context) error {
+				return trendingService.SyncTrending(ctx, trendingOptions)
+			}},
+			{Name: "ohlc", Schedule: cfg.Scheduler.OHLC, Run: func(ctx context.Context) error {
+				return coinOHLCService.SyncOHLC(ctx, ohlcOptions)
+			}},
+		}
+		for _, job := range jobs {
+			if err := sched.Add(job); err != nil {
+				log.WithError(err).Fatal("Failed to configure scheduler")
+			}
+		}
+		sched.Start(ctx, cfg.Scheduler.RunOnStart)
+
+		serveHTTP(ctx, server)
+
+		// ctx is cancelled by now, so running syncs are already winding down
+		stopCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := sched.Stop(stopCtx); err != nil {
+			log.WithError(err).Error("Failed to stop scheduler cleanly")
+		}
+
+		log.Info("Application stopped gracefully")
+		return
+	}
+
 	// Run initial sync
 	log.Info("Running initial asset platforms synchronization")
-	if err := assetPlatformService.SyncAssetPlatforms(); err != nil {
+	if err := assetPlatformService.SyncAssetPlatforms(ctx); err != nil {
 		log.WithError(err).Error("Failed to sync asset platforms")
 		// Don't exit on sync failure, continue running
 	}
 
-	// Set up graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	serveHTTP(ctx, server)
+
+	log.Info("Application stopped gracefully")
+}
+
+// serveHTTP runs the server until the context is cancelled or the server fails, then shuts it down
+func serveHTTP(ctx context.Context, server *http.Server) {
+	log := logger.GetLogger()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.WithField("addr", server.Addr).Info("Starting HTTP server")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
 
 	log.Info("Application started successfully. Press Ctrl+C to stop.")
 
-	// Wait for shutdown signal
-	<-sigChan
-	log.Info("Shutdown signal received, stopping application...")
+	// Wait for shutdown signal or server failure
+	select {
+	case <-ctx.Done():
+		log.Info("Shutdown signal received, stopping application...")
+	case err := <-serverErr:
+		log.WithError(err).Error("HTTP server failed")
+	}
 
-	// Perform cleanup here if needed
-	log.Info("Application stopped gracefully")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.WithError(err).Error("Failed to shut down HTTP server")
+	}
+}
+
+// printContractResolutions resolves comma-separated PLATFORM/ADDRESS pairs and prints the coin behind each
+func printContractResolutions(ctx context.Context, contractService service.ContractService, value string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PLATFORM\tADDRESS\tCOIN\tSOURCE")
+	for _, pair := range strings.Split(value, ",") {
+		platformID, address, ok := strings.Cut(strings.TrimSpace(pair), "/")
+		if !ok {
+			return fmt.Errorf("invalid -resolve-contract %q (expected PLATFORM/ADDRESS)", pair)
+		}
+		resolution, err := contractService.ResolveContract(ctx, platformID, address)
+		if err != nil {
+			return err
+		}
+
+		coin := "(not found)"
+		if resolution.CoingeckoID != nil {
+			coin = *resolution.CoingeckoID
+		}
+		source := resolution.Source
+		if source == "" {
+			source = "(offline)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", resolution.AssetPlatformID, resolution.ContractAddress, coin, source)
+	}
+	return w.Flush()
+}
+
+// printSearchResults searches CoinGecko and prints the matching coins, exchanges, categories and NFTs
+func printSearchResults(ctx context.Context, trendingService service.TrendingService, query string) error {
+	result, err := trendingService.Search(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tID\tSYMBOL\tNAME\tMARKET CAP RANK")
+	for _, coin := range result.Coins {
+		fmt.Fprintf(w, "coin\t%s\t%s\t%s\t%s\n", coin.ID, coin.Symbol, coin.Name, formatCount(coin.MarketCapRank))
+	}
+	for _, exchange := range result.Exchanges {
+		fmt.Fprintf(w, "exchange\t%s\t-\t%s\t-\n", exchange.ID, exchange.Name)
+	}
+	for _, category := range result.Categories {
+		fmt.Fprintf(w, "category\t-\t-\t%s\t-\n", category.Name)
+	}
+	for _, nft := range result.NFTs {
+		fmt.Fprintf(w, "nft\t%s\t%s\t%s\t-\n", nft.ID, nft.Symbol, nft.Name)
+	}
+	if len(result.Coins)+len(result.Exchanges)+len(result.Categories)+len(result.NFTs) == 0 {
+		fmt.Fprintln(w, "(none)")
+	}
+	return w.Flush()
+}
+
+// printGlobalHistory prints the global market snapshots of the last days, oldest first
+func printGlobalHistory(repo repository.GlobalSnapshotRepository, days int) error {
+	to := time.Now().UTC()
+	snapshots, err := repo.GetRange(to.AddDate(0, 0, -days), to)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CAPTURED\tMARKET CAP USD\tVOLUME USD\tBTC %\tETH %\tACTIVE COINS\tDEFI MARKET CAP\tDEFI %")
+	for _, s := range snapshots {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.CapturedAt.UTC().Format(time.RFC3339), formatFigure(s.TotalMarketCapUSD, 0), formatFigure(s.TotalVolumeUSD, 0),
+			formatFigure(s.BTCDominance, 2), formatFigure(s.ETHDominance, 2), formatCount(s.ActiveCryptocurrencies),
+			formatFigure(s.DefiMarketCap, 0), formatFigure(s.DefiDominance, 2))
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintln(w, "(none)")
+	}
+	return w.Flush()
+}
+
+// formatFigure formats an optional number with the given precision, or "-" when it is missing
+func formatFigure(v *float64, precision int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*v, 'f', precision, 64)
+}
+
+// formatCount formats an optional count, or "-" when it is missing
+func formatCount(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v)
+}
+
+// printSyncHistory prints the latest successful run of every sync kind followed by the most recent runs
+func printSyncHistory(repo repository.SyncRunRepository, kind string, limit int) error {
+	latest, err := repo.GetLatestSucceeded()
+	if err != nil {
+		return err
+	}
+	runs, err := repo.ListRecent(kind, limit)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "Last successful sync per kind:")
+	fmt.Fprintln(w, "KIND\tFINISHED\tAGE")
+	for _, run := range latest {
+		if run.FinishedAt == nil {
+			fmt.Fprintf(w, "%s\trunning\t-\n", run.Kind)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", run.Kind, run.FinishedAt.Format(time.RFC3339), time.Since(*run.FinishedAt).Truncate(time.Second))
+	}
+	if len(latest) == 0 {
+		fmt.Fprintln(w, "(none)")
+	}
+
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Recent runs:")
+	fmt.Fprintln(w, "ID\tKIND\tSTATUS\tSTARTED\tDURATION\tPAGES\tINSERTED\tUPDATED\tSKIPPED\tAPI CALLS\tERROR")
+	for _, run := range runs {
+		errText := ""
+		if run.Error != nil {
+			// Keep the table readable; the full text stays in sync_runs.error
+			errText = strings.Join(strings.Fields(*run.Error), " ")
+			if len(errText) > 120 {
+				errText = errText[:117] + "..."
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			run.ID, run.Kind, run.Status, run.StartedAt.Format(time.RFC3339), run.Duration().Truncate(time.Second),
+			run.PagesFetched, run.RowsInserted, run.RowsUpdated, run.RowsSkipped, run.APICalls, errText)
+	}
+	if len(runs) == 0 {
+		fmt.Fprintln(w, "(none)")
+	}
+
+	return w.Flush()
+}
+
+// parseBackfillRange parses the -backfill-from/-backfill-to dates, defaulting to the last 365 days
+func parseBackfillRange(fromValue, toValue string) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if toValue != "" {
+		t, err := time.Parse("2006-01-02", toValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid -backfill-to %q: %w", toValue, err)
+		}
+		to = t
+	}
+
+	from := to.AddDate(0, 0, -365)
+	if fromValue != "" {
+		t, err := time.Parse("2006-01-02", fromValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid -backfill-from %q: %w", fromValue, err)
+		}
+		from = t
+	}
+
+	return from, to, nil
 }
 
 // printUsage prints usage information
@@ -200,10 +746,33 @@ func printUsage() {
 	fmt.Println("Options:")
 	fmt.Println("  -sync-platforms   Only sync asset platforms and exit")
 	fmt.Println("  -sync-categories  Only sync coin categories and exit")
+	fmt.Println("  -sync-categories-market  Sync coin category market data and append category snapshots and exit")
 	fmt.Println("  -sync-exchanges   Only sync exchanges and exit")
+	fmt.Println("  -sync-exchanges-data  Sync exchange details, tickers and volume charts (filtered by trust score and volume) and exit")
+	fmt.Println("  -sync-nfts        Only sync the NFT collection list and exit")
+	fmt.Println("  -sync-nfts-data   Sync NFT collection details and market snapshots (filtered by market cap) and exit")
+	fmt.Println("  -sync-derivatives-exchanges  Only sync derivatives exchanges and exit")
+	fmt.Println("  -sync-derivatives Sync derivative contracts and append funding rate snapshots and exit")
 	fmt.Println("  -sync-coins-data  Sync full coin data and tickers (filtered by volume) and exit")
 	fmt.Println("  -sync-coins       Only sync coins and their market data and exit")
+	fmt.Println("  -sync-coin-list   Sync every listed coin id, including inactive coins, and exit")
 	fmt.Println("  -sync-all         Sync asset platforms, coin categories, exchanges, and coins and exit")
+	fmt.Println("  -sync-ohlc        Sync OHLC candles (filtered by volume) and exit")
+	fmt.Println("  -sync-global      Capture a global market and DeFi snapshot and exit")
+	fmt.Println("  -sync-exchange-rates  Capture a snapshot of the BTC exchange rates and exit")
+	fmt.Println("  -global-history   Show recent global market snapshots and exit")
+	fmt.Println("    -global-history-days N       Number of days shown (default: 7)")
+	fmt.Println("  -sync-trending    Capture the trending lists (and top gainers/losers on the pro plan) and exit")
+	fmt.Println("  -search QUERY     Search CoinGecko for coins, exchanges, categories and NFTs and exit")
+	fmt.Println("  -backfill-history Backfill historical market charts (filtered by volume) and exit")
+	fmt.Println("    -backfill-from YYYY-MM-DD    Start date (default: 365 days before -backfill-to)")
+	fmt.Println("    -backfill-to YYYY-MM-DD      End date, exclusive (default: today)")
+	fmt.Println("    -backfill-granularity daily|hourly  Point granularity (default: daily)")
+	fmt.Println("  -resolve-contract PLATFORM/ADDRESS[,...]  Resolve contract addresses to coins and exit")
+	fmt.Println("  -sync-history     Show recent sync runs and exit")
+	fmt.Println("    -sync-history-limit N        Number of runs shown (default: 20)")
+	fmt.Println("    -sync-history-kind KIND      Only show runs of this kind")
+	fmt.Println("  -daemon           Run scheduled syncs and serve the HTTP API until stopped")
 	fmt.Println("  -migrate          Run database migrations and exit")
 	fmt.Println("  -rollback         Rollback last migration and exit")
 	fmt.Println("  -status           Show migration status and exit")
@@ -217,9 +786,16 @@ func printUsage() {
 	fmt.Println("  DB_SSLMODE           Database SSL mode (default: disable)")
 	fmt.Println("  DB_TIMEZONE          Database timezone (default: UTC)")
 	fmt.Println("  COINGECKO_BASE_URL   CoinGecko API base URL (default: https://api.coingecko.com/api/v3)")
+	fmt.Println("  COINGECKO_API_KEY    CoinGecko Demo or Pro API key")
+	fmt.Println("  COINGECKO_API_PLAN   CoinGecko plan: public, demo or pro (default: public)")
 	fmt.Println("  API_TIMEOUT          API timeout (default: 30s)")
 	fmt.Println("  API_RETRY_ATTEMPTS   API retry attempts (default: 3)")
 	fmt.Println("  API_RETRY_DELAY      API retry delay (default: 1s)")
+	fmt.Println("  OHLC_INTERVAL        OHLC candle interval: 30m, 4h, 4d, or hourly/daily on the pro plan (default: 4h)")
+	fmt.Println("  SERVER_HOST          HTTP server host (default: 0.0.0.0)")
+	fmt.Println("  SERVER_PORT          HTTP server port (default: 8080)")
+	fmt.Println("  SERVER_COINGECKO_COMPAT  Serve CoinGecko-compatible /api/v3 routes (default: true)")
+	fmt.Println("  SCHEDULE_*           Daemon schedules: cron expression, @every <duration>, duration, or off")
 	fmt.Println("  LOG_LEVEL            Log level (default: info)")
 	fmt.Println("  LOG_FORMAT           Log format (default: json)")
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
@@ -29,6 +30,7 @@ type AssetPlatformRepository interface {
 	CreateBatch(platforms []AssetPlatform) error
 	GetByID(id string) (*AssetPlatform, error)
 	GetAll() ([]AssetPlatform, error)
+	List(opts ListOptions) ([]AssetPlatform, int64, error)
 	Update(platform *AssetPlatform) error
 	Delete(id string) error
 	Upsert(platform *AssetPlatform) error
@@ -37,8 +39,8 @@ type AssetPlatformRepository interface {
 
 // AssetPlatformService defines the interface for asset platform business logic
 type AssetPlatformService interface {
-	FetchAndStoreAssetPlatforms() error
+	FetchAndStoreAssetPlatforms(ctx context.Context) error
 	GetAllAssetPlatforms() ([]AssetPlatform, error)
 	GetAssetPlatformByID(id string) (*AssetPlatform, error)
-	SyncAssetPlatforms() error
+	SyncAssetPlatforms(ctx context.Context) error
 }
@@ -8,49 +8,75 @@ import (
 
 // Coin represents a cryptocurrency from CoinGecko
 type Coin struct {
-	ID                           uint           `gorm:"primaryKey"`
-	CoingeckoID                  string         `gorm:"uniqueIndex;size:100;not null"`
-	Symbol                       string         `gorm:"size:20;not null"`
-	Name                         string         `gorm:"size:255;not null"`
-	Image                        *string        `gorm:"size:500"`
-	CurrentPrice                 *float64       `gorm:"column:current_price"`
-	MarketCap                    *float64       `gorm:"column:market_cap"`
-	MarketCapRank                *int           `gorm:"column:market_cap_rank"`
-	FullyDilutedValuation        *float64       `gorm:"column:fully_diluted_valuation"`
-	TotalVolume                  *float64       `gorm:"column:total_volume"`
-	High24h                      *float64       `gorm:"column:high_24h"`
-	Low24h                       *float64       `gorm:"column:low_24h"`
-	PriceChange24h               *float64       `gorm:"column:price_change_24h"`
-	PriceChangePercentage24h     *float64       `gorm:"column:price_change_percentage_24h"`
-	MarketCapChange24h           *float64       `gorm:"column:market_cap_change_24h"`
-	MarketCapChangePercentage24h *float64       `gorm:"column:market_cap_change_percentage_24h"`
-	CirculatingSupply            *float64       `gorm:"column:circulating_supply"`
-	TotalSupply                  *float64       `gorm:"column:total_supply"`
-	MaxSupply                    *float64       `gorm:"column:max_supply"`
-	Ath                          *float64       `gorm:"column:ath"`
-	AthChangePercentage          *float64       `gorm:"column:ath_change_percentage"`
-	AthDate                      *time.Time     `gorm:"column:ath_date"`
-	Atl                          *float64       `gorm:"column:atl"`
-	AtlChangePercentage          *float64       `gorm:"column:atl_change_percentage"`
-	AtlDate                      *time.Time     `gorm:"column:atl_date"`
-	LastUpdated                  *time.Time     `gorm:"column:last_updated"`
-	CreatedAt                    time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt                    time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt                    gorm.DeletedAt `gorm:"index"`
+	ID                           uint           `json:"id" gorm:"primaryKey"`
+	CoingeckoID                  string         `json:"coingecko_id" gorm:"uniqueIndex;size:100;not null"`
+	Symbol                       string         `json:"symbol" gorm:"size:20;not null"`
+	Name                         string         `json:"name" gorm:"size:255;not null"`
+	Active                       bool           `json:"active" gorm:"not null;index"` // false for coins CoinGecko lists as inactive
+	Image                        *string        `json:"image" gorm:"size:500"`
+	CurrentPrice                 *float64       `json:"current_price" gorm:"column:current_price"`
+	MarketCap                    *float64       `json:"market_cap" gorm:"column:market_cap"`
+	MarketCapRank                *int           `json:"market_cap_rank" gorm:"column:market_cap_rank"`
+	FullyDilutedValuation        *float64       `json:"fully_diluted_valuation" gorm:"column:fully_diluted_valuation"`
+	TotalVolume                  *float64       `json:"total_volume" gorm:"column:total_volume"`
+	High24h                      *float64       `json:"high_24h" gorm:"column:high_24h"`
+	Low24h                       *float64       `json:"low_24h" gorm:"column:low_24h"`
+	PriceChange24h               *float64       `json:"price_change_24h" gorm:"column:price_change_24h"`
+	PriceChangePercentage24h     *float64       `json:"price_change_percentage_24h" gorm:"column:price_change_percentage_24h"`
+	MarketCapChange24h           *float64       `json:"market_cap_change_24h" gorm:"column:market_cap_change_24h"`
+	MarketCapChangePercentage24h *float64       `json:"market_cap_change_percentage_24h" gorm:"column:market_cap_change_percentage_24h"`
+	CirculatingSupply            *float64       `json:"circulating_supply" gorm:"column:circulating_supply"`
+	TotalSupply                  *float64       `json:"total_supply" gorm:"column:total_supply"`
+	MaxSupply                    *float64       `json:"max_supply" gorm:"column:max_supply"`
+	Ath                          *float64       `json:"ath" gorm:"column:ath"`
+	AthChangePercentage          *float64       `json:"ath_change_percentage" gorm:"column:ath_change_percentage"`
+	AthDate                      *time.Time     `json:"ath_date" gorm:"column:ath_date"`
+	Atl                          *float64       `json:"atl" gorm:"column:atl"`
+	AtlChangePercentage          *float64       `json:"atl_change_percentage" gorm:"column:atl_change_percentage"`
+	AtlDate                      *time.Time     `json:"atl_date" gorm:"column:atl_date"`
+	ROI                          *CoinROI       `json:"roi" gorm:"column:roi;type:jsonb;serializer:json"` // nil for coins without an ICO price
+	LastUpdated                  *time.Time     `json:"last_updated" gorm:"column:last_updated"`
+	CreatedAt                    time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt                    time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt                    gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
-// CoinMarketData represents market data for a coin on a specific exchange
+// CoinROI is the return on investment /coins/markets reports for coins that had an ICO
+type CoinROI struct {
+	Times      *float64 `json:"times"`
+	Currency   string   `json:"currency"`
+	Percentage *float64 `json:"percentage"`
+}
+
+// Sources of coin_market_data rows: the sync that last wrote a ticker, which is the only one that prunes it
+const (
+	MarketDataSourceCoin     = "coin"     // /coins/{id}/tickers
+	MarketDataSourceExchange = "exchange" // /exchanges/{id}/tickers
+)
+
+// CoinMarketData represents one ticker of a coin: a base/target pair traded on a specific exchange
 type CoinMarketData struct {
-	ID               uint           `gorm:"primaryKey"`
-	CoinID           uint           `gorm:"not null;index"`
-	ExchangeID       uint           `gorm:"not null;index"`
-	Coin             Coin           `gorm:"foreignKey:CoinID"`
-	Exchange         Exchange       `gorm:"foreignKey:ExchangeID"`
-	Price            *float64       `gorm:"not null"`
-	Volume24h        *float64       `gorm:"column:volume_24h"`
-	VolumePercentage *float64       `gorm:"column:volume_percentage"`
-	LastUpdated      *time.Time     `gorm:"column:last_updated"`
-	CreatedAt        time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt        time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt        gorm.DeletedAt `gorm:"index"`
+	ID                     uint           `json:"id" gorm:"primaryKey"`
+	CoinID                 uint           `json:"coin_id" gorm:"not null;index;uniqueIndex:idx_coin_market_data_ticker,priority:1"`
+	ExchangeID             uint           `json:"exchange_id" gorm:"not null;index;uniqueIndex:idx_coin_market_data_ticker,priority:2"`
+	Coin                   Coin           `json:"coin,omitempty" gorm:"foreignKey:CoinID"`
+	Exchange               Exchange       `json:"exchange,omitempty" gorm:"foreignKey:ExchangeID"`
+	Base                   string         `json:"base" gorm:"type:varchar(100);not null;default:'';index:idx_coin_market_data_pair,priority:1;uniqueIndex:idx_coin_market_data_ticker,priority:3"`
+	Target                 string         `json:"target" gorm:"type:varchar(100);not null;default:'';index:idx_coin_market_data_pair,priority:2;uniqueIndex:idx_coin_market_data_ticker,priority:4"`
+	Source                 string         `json:"source" gorm:"type:varchar(20);not null;default:'coin';index"`
+	Price                  *float64       `json:"price" gorm:"not null"`               // last price in the target currency
+	Volume24h              *float64       `json:"volume_24h" gorm:"column:volume_24h"` // 24h volume in the base currency
+	VolumePercentage       *float64       `json:"volume_percentage" gorm:"column:volume_percentage"`
+	ConvertedLastUSD       *float64       `json:"converted_last_usd" gorm:"column:converted_last_usd"`
+	ConvertedVolumeUSD     *float64       `json:"converted_volume_usd" gorm:"column:converted_volume_usd"`
+	BidAskSpreadPercentage *float64       `json:"bid_ask_spread_percentage" gorm:"column:bid_ask_spread_percentage"`
+	TrustScore             *string        `json:"trust_score" gorm:"type:varchar(20);index"`
+	IsAnomaly              bool           `json:"is_anomaly" gorm:"not null;default:false"`
+	IsStale                bool           `json:"is_stale" gorm:"not null;default:false"`
+	LastUpdated            *time.Time     `json:"last_updated" gorm:"column:last_updated"` // ticker timestamp
+	LastTradedAt           *time.Time     `json:"last_traded_at" gorm:"column:last_traded_at"`
+	LastFetchAt            *time.Time     `json:"last_fetch_at" gorm:"column:last_fetch_at"`
+	CreatedAt              time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt              time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt              gorm.DeletedAt `json:"-" gorm:"index"`
 }
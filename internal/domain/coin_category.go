@@ -1,19 +1,28 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-// CoinCategory represents a coin category from CoinGecko API
+// CoinCategory represents a coin category from CoinGecko API.
+// The market fields come from /coins/categories and stay empty until a categories market sync has seen the category.
 type CoinCategory struct {
-	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
-	CoingeckoID string       `json:"coingecko_id" gorm:"type:varchar(100);uniqueIndex;not null"`
-	Name      string         `json:"name" gorm:"type:varchar(255);not null"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID                  uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CoingeckoID         string         `json:"coingecko_id" gorm:"type:varchar(100);uniqueIndex;not null"`
+	Name                string         `json:"name" gorm:"type:varchar(255);not null"`
+	Content             *string        `json:"content" gorm:"type:text"`
+	MarketCap           *float64       `json:"market_cap"`
+	MarketCapChange24h  *float64       `json:"market_cap_change_24h" gorm:"column:market_cap_change_24h"`
+	Volume24h           *float64       `json:"volume_24h" gorm:"column:volume_24h"`
+	Top3CoinIDs         []string       `json:"top_3_coins_id" gorm:"column:top_3_coin_ids;type:jsonb;serializer:json"`
+	Top3Coins           []string       `json:"top_3_coins" gorm:"column:top_3_coins;type:jsonb;serializer:json"` // image URLs
+	MarketDataUpdatedAt *time.Time     `json:"market_data_updated_at" gorm:"type:timestamptz"`                   // CoinGecko's updated_at
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 // TableName returns the table name for the CoinCategory model
@@ -27,18 +36,39 @@ type CoinCategoryRepository interface {
 	CreateBatch(categories []CoinCategory) error
 	GetByID(id uint) (*CoinCategory, error)
 	GetByCoingeckoID(coingeckoID string) (*CoinCategory, error)
+	GetIDsByCoingeckoIDs(coingeckoIDs []string) (map[string]uint, error)
 	GetAll() ([]CoinCategory, error)
+	List(opts ListOptions) ([]CoinCategory, int64, error)
 	Update(category *CoinCategory) error
 	Delete(id uint) error
 	Upsert(category *CoinCategory) error
 	UpsertBatch(categories []CoinCategory) error
+	UpsertMarketData(categories []CoinCategory) error
+	MarkDelisted(listed []string) (int64, error)
 }
 
 // CoinCategoryService defines the interface for coin category business logic
 type CoinCategoryService interface {
-	FetchAndStoreCoinCategories() error
+	FetchAndStoreCoinCategories(ctx context.Context) error
 	GetAllCoinCategories() ([]CoinCategory, error)
 	GetCoinCategoryByID(id uint) (*CoinCategory, error)
 	GetCoinCategoryByCoingeckoID(coingeckoID string) (*CoinCategory, error)
-	SyncCoinCategories() error
+	SyncCoinCategories(ctx context.Context) error
+	SyncCoinCategoriesMarket(ctx context.Context) error
+}
+
+// CategorySnapshot is a point-in-time copy of a coin category's market figures, appended on every categories market sync
+type CategorySnapshot struct {
+	ID                 uint      `json:"id" gorm:"primaryKey"`
+	CategoryID         uint      `json:"category_id" gorm:"not null;uniqueIndex:idx_category_snapshots_key,priority:1"` // FK to coin_categories(id)
+	CapturedAt         time.Time `json:"captured_at" gorm:"type:timestamptz;not null;uniqueIndex:idx_category_snapshots_key,priority:2"`
+	MarketCap          *float64  `json:"market_cap"`
+	MarketCapChange24h *float64  `json:"market_cap_change_24h" gorm:"column:market_cap_change_24h"`
+	Volume24h          *float64  `json:"volume_24h" gorm:"column:volume_24h"`
+	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the CategorySnapshot model
+func (CategorySnapshot) TableName() string {
+	return "category_snapshots"
 }
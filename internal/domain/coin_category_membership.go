@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+)
+
+// CoinCategoryMembership links a coin to one of the categories it belongs to.
+// Rows are derived from the category names in coin_details, matched against coin_categories by name.
+type CoinCategoryMembership struct {
+	CoinID     uint      `json:"coin_id" gorm:"primaryKey;autoIncrement:false"`
+	CategoryID uint      `json:"category_id" gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the CoinCategoryMembership model
+func (CoinCategoryMembership) TableName() string {
+	return "coin_category_memberships"
+}
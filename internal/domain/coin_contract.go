@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// CoinContract is the token contract of a coin on one asset platform, taken from the platforms of /coins/{id}
+type CoinContract struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	CoinID          uint      `json:"coin_id" gorm:"not null;uniqueIndex:idx_coin_contracts_key,priority:1"`
+	AssetPlatformID string    `json:"asset_platform_id" gorm:"type:varchar(50);not null;uniqueIndex:idx_coin_contracts_key,priority:2;index:idx_coin_contracts_address,priority:1"`
+	ContractAddress string    `json:"contract_address" gorm:"type:varchar(255);not null;index:idx_coin_contracts_address,priority:2"`
+	DecimalPlace    *int      `json:"decimal_place"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the CoinContract model
+func (CoinContract) TableName() string {
+	return "coin_contracts"
+}
+
+// NormalizeContractAddress returns the form contract addresses are stored and looked up in.
+// Hex addresses are lowercased because EVM chains ignore their case; others, such as base58 ones, are case-sensitive.
+func NormalizeContractAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestNormalizeContractAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{name: "empty", address: "", want: ""},
+		{name: "hex is lowercased", address: "0xA0b86991C6218b36c1d19D4a2e9Eb0cE3606eB48", want: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"},
+		{name: "uppercase hex prefix", address: "0XABCDEF", want: "0xabcdef"},
+		{name: "surrounding space is trimmed", address: "  0xAbC \n", want: "0xabc"},
+		{name: "base58 keeps its case", address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", want: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"},
+		{name: "non-hex is only trimmed", address: " TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t ", want: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeContractAddress(tt.address); got != tt.want {
+				t.Errorf("NormalizeContractAddress(%q) = %q, want %q", tt.address, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// Granularities of backfilled market chart points
+const (
+	ChartGranularityDaily  = "daily"
+	ChartGranularityHourly = "hourly"
+)
+
+// CoinMarketChartPoint is one bucket of historical market data from /coins/{id}/market_chart.
+// Timestamp is the start of the bucket (UTC midnight for daily, top of the hour for hourly).
+type CoinMarketChartPoint struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	CoinID      uint      `json:"coin_id" gorm:"not null;uniqueIndex:idx_coin_market_chart_points_key,priority:1"`
+	VsCurrency  string    `json:"vs_currency" gorm:"size:20;not null;uniqueIndex:idx_coin_market_chart_points_key,priority:2"`
+	Granularity string    `json:"granularity" gorm:"size:10;not null;uniqueIndex:idx_coin_market_chart_points_key,priority:3"`
+	Timestamp   time.Time `json:"timestamp" gorm:"type:timestamptz;not null;uniqueIndex:idx_coin_market_chart_points_key,priority:4"`
+	Price       *float64  `json:"price" gorm:"column:price"`
+	MarketCap   *float64  `json:"market_cap" gorm:"column:market_cap"`
+	TotalVolume *float64  `json:"total_volume" gorm:"column:total_volume"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// CoinMarketChartCoverage records where CoinGecko's history of a coin series starts.
+// Buckets before AvailableFrom were requested and came back empty, usually because the coin was not listed yet,
+// so backfills start at AvailableFrom instead of requesting them again.
+type CoinMarketChartCoverage struct {
+	CoinID        uint      `json:"coin_id" gorm:"primaryKey"`
+	VsCurrency    string    `json:"vs_currency" gorm:"size:20;primaryKey"`
+	Granularity   string    `json:"granularity" gorm:"size:10;primaryKey"`
+	AvailableFrom time.Time `json:"available_from" gorm:"type:timestamptz;not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the CoinMarketChartCoverage model
+func (CoinMarketChartCoverage) TableName() string {
+	return "coin_market_chart_coverage"
+}
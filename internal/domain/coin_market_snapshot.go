@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+)
+
+// CoinMarketSnapshot is a point-in-time copy of a coin's market data, appended on every coins sync
+type CoinMarketSnapshot struct {
+	ID                    uint       `json:"id" gorm:"primaryKey"`
+	CoinID                uint       `json:"coin_id" gorm:"not null;uniqueIndex:idx_coin_market_snapshots_coin_captured,priority:1"`
+	CapturedAt            time.Time  `json:"captured_at" gorm:"type:timestamptz;not null;uniqueIndex:idx_coin_market_snapshots_coin_captured,priority:2;index"`
+	Price                 *float64   `json:"price" gorm:"column:price"`
+	MarketCap             *float64   `json:"market_cap" gorm:"column:market_cap"`
+	TotalVolume           *float64   `json:"total_volume" gorm:"column:total_volume"`
+	FullyDilutedValuation *float64   `json:"fully_diluted_valuation" gorm:"column:fully_diluted_valuation"`
+	CirculatingSupply     *float64   `json:"circulating_supply" gorm:"column:circulating_supply"`
+	TotalSupply           *float64   `json:"total_supply" gorm:"column:total_supply"`
+	MaxSupply             *float64   `json:"max_supply" gorm:"column:max_supply"`
+	SourceUpdatedAt       *time.Time `json:"source_updated_at" gorm:"column:source_updated_at"`
+	CreatedAt             time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+)
+
+// OHLC candle intervals. 30m, 4h and 4d are what /coins/{id}/ohlc returns for a given days value;
+// hourly and daily are requested explicitly through /coins/{id}/ohlc/range on paid plans.
+const (
+	OHLCInterval30m    = "30m"
+	OHLCInterval4h     = "4h"
+	OHLCInterval4d     = "4d"
+	OHLCIntervalHourly = "hourly"
+	OHLCIntervalDaily  = "daily"
+)
+
+// CoinOHLC is one OHLC candle of a coin quoted in vs_currency
+type CoinOHLC struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	CoinID     uint      `json:"coin_id" gorm:"not null;uniqueIndex:idx_coin_ohlc_key,priority:1"`
+	VsCurrency string    `json:"vs_currency" gorm:"size:20;not null;uniqueIndex:idx_coin_ohlc_key,priority:2"`
+	Interval   string    `json:"interval" gorm:"size:10;not null;uniqueIndex:idx_coin_ohlc_key,priority:3"`
+	OpenTime   time.Time `json:"open_time" gorm:"type:timestamptz;not null;uniqueIndex:idx_coin_ohlc_key,priority:4"`
+	Open       float64   `json:"open" gorm:"not null"`
+	High       float64   `json:"high" gorm:"not null"`
+	Low        float64   `json:"low" gorm:"not null"`
+	Close      float64   `json:"close" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the CoinOHLC model
+func (CoinOHLC) TableName() string {
+	return "coin_ohlc"
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"time"
+)
+
+// CoinQuote holds the currency-dependent /coins/markets figures of a coin in one vs_currency.
+// Supplies and ranks do not depend on the currency and stay on Coin, whose own figures are in USD.
+type CoinQuote struct {
+	ID                           uint       `json:"id" gorm:"primaryKey"`
+	CoinID                       uint       `json:"coin_id" gorm:"not null;uniqueIndex:idx_coin_quotes_key,priority:1"`
+	VsCurrency                   string     `json:"vs_currency" gorm:"type:varchar(20);not null;uniqueIndex:idx_coin_quotes_key,priority:2;index"`
+	CurrentPrice                 *float64   `json:"current_price" gorm:"column:current_price"`
+	MarketCap                    *float64   `json:"market_cap" gorm:"column:market_cap"`
+	FullyDilutedValuation        *float64   `json:"fully_diluted_valuation" gorm:"column:fully_diluted_valuation"`
+	TotalVolume                  *float64   `json:"total_volume" gorm:"column:total_volume"`
+	High24h                      *float64   `json:"high_24h" gorm:"column:high_24h"`
+	Low24h                       *float64   `json:"low_24h" gorm:"column:low_24h"`
+	PriceChange24h               *float64   `json:"price_change_24h" gorm:"column:price_change_24h"`
+	PriceChangePercentage24h     *float64   `json:"price_change_percentage_24h" gorm:"column:price_change_percentage_24h"`
+	MarketCapChange24h           *float64   `json:"market_cap_change_24h" gorm:"column:market_cap_change_24h"`
+	MarketCapChangePercentage24h *float64   `json:"market_cap_change_percentage_24h" gorm:"column:market_cap_change_percentage_24h"`
+	Ath                          *float64   `json:"ath" gorm:"column:ath"`
+	AthChangePercentage          *float64   `json:"ath_change_percentage" gorm:"column:ath_change_percentage"`
+	AthDate                      *time.Time `json:"ath_date" gorm:"column:ath_date"`
+	Atl                          *float64   `json:"atl" gorm:"column:atl"`
+	AtlChangePercentage          *float64   `json:"atl_change_percentage" gorm:"column:atl_change_percentage"`
+	AtlDate                      *time.Time `json:"atl_date" gorm:"column:atl_date"`
+	LastUpdated                  *time.Time `json:"last_updated" gorm:"column:last_updated"`
+	CreatedAt                    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt                    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// NewCoinQuote takes the currency-dependent figures of a /coins/markets row fetched in vsCurrency
+func NewCoinQuote(coinID uint, vsCurrency string, c Coin) CoinQuote {
+	return CoinQuote{
+		CoinID:                       coinID,
+		VsCurrency:                   vsCurrency,
+		CurrentPrice:                 c.CurrentPrice,
+		MarketCap:                    c.MarketCap,
+		FullyDilutedValuation:        c.FullyDilutedValuation,
+		TotalVolume:                  c.TotalVolume,
+		High24h:                      c.High24h,
+		Low24h:                       c.Low24h,
+		PriceChange24h:               c.PriceChange24h,
+		PriceChangePercentage24h:     c.PriceChangePercentage24h,
+		MarketCapChange24h:           c.MarketCapChange24h,
+		MarketCapChangePercentage24h: c.MarketCapChangePercentage24h,
+		Ath:                          c.Ath,
+		AthChangePercentage:          c.AthChangePercentage,
+		AthDate:                      c.AthDate,
+		Atl:                          c.Atl,
+		AtlChangePercentage:          c.AtlChangePercentage,
+		AtlDate:                      c.AtlDate,
+		LastUpdated:                  c.LastUpdated,
+	}
+}
+
+// ApplyTo replaces the currency-dependent figures of c with the quote's.
+// The zero CoinQuote clears them, for coins without a quote in the requested currency.
+func (q CoinQuote) ApplyTo(c *Coin) {
+	c.CurrentPrice = q.CurrentPrice
+	c.MarketCap = q.MarketCap
+	c.FullyDilutedValuation = q.FullyDilutedValuation
+	c.TotalVolume = q.TotalVolume
+	c.High24h = q.High24h
+	c.Low24h = q.Low24h
+	c.PriceChange24h = q.PriceChange24h
+	c.PriceChangePercentage24h = q.PriceChangePercentage24h
+	c.MarketCapChange24h = q.MarketCapChange24h
+	c.MarketCapChangePercentage24h = q.MarketCapChangePercentage24h
+	c.Ath = q.Ath
+	c.AthChangePercentage = q.AthChangePercentage
+	c.AthDate = q.AthDate
+	c.Atl = q.Atl
+	c.AtlChangePercentage = q.AtlChangePercentage
+	c.AtlDate = q.AtlDate
+	c.LastUpdated = q.LastUpdated
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+)
+
+// ContractLookup caches what CoinGecko answered for a contract address that was not found locally.
+// CoingeckoID is nil when CoinGecko does not list the contract; CheckedAt tells when such a miss may be retried.
+type ContractLookup struct {
+	AssetPlatformID string    `json:"asset_platform_id" gorm:"type:varchar(50);primaryKey"`
+	ContractAddress string    `json:"contract_address" gorm:"type:varchar(255);primaryKey"`
+	CoingeckoID     *string   `json:"coingecko_id" gorm:"type:varchar(100)"`
+	CheckedAt       time.Time `json:"checked_at" gorm:"not null"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the ContractLookup model
+func (ContractLookup) TableName() string {
+	return "contract_lookups"
+}
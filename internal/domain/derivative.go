@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Contract types of derivative contracts
+const (
+	DerivativeContractPerpetual = "perpetual"
+	DerivativeContractFutures   = "futures"
+)
+
+// DerivativesExchange represents a derivatives exchange from /derivatives/exchanges
+type DerivativesExchange struct {
+	ID                     uint           `json:"id" gorm:"primaryKey"`
+	CoingeckoID            string         `json:"coingecko_id" gorm:"uniqueIndex;size:100;not null"`
+	Name                   string         `json:"name" gorm:"size:255;not null;index"`
+	OpenInterestBTC        *float64       `json:"open_interest_btc" gorm:"column:open_interest_btc"`
+	TradeVolume24hBTC      *float64       `json:"trade_volume_24h_btc" gorm:"column:trade_volume_24h_btc"`
+	NumberOfPerpetualPairs *int           `json:"number_of_perpetual_pairs"`
+	NumberOfFuturesPairs   *int           `json:"number_of_futures_pairs"`
+	YearEstablished        *int           `json:"year_established"`
+	Country                *string        `json:"country" gorm:"size:100"`
+	Description            *string        `json:"description" gorm:"type:text"`
+	URL                    *string        `json:"url" gorm:"size:500"`
+	Image                  *string        `json:"image" gorm:"size:500"`
+	CreatedAt              time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt              time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt              gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for the DerivativesExchange model
+func (DerivativesExchange) TableName() string {
+	return "derivatives_exchanges"
+}
+
+// DerivativeContract is the latest state of a derivative contract from /derivatives, identified by market and symbol.
+// Market is the display name of the derivatives exchange, which /derivatives reports instead of its id.
+type DerivativeContract struct {
+	ID                       uint       `json:"id" gorm:"primaryKey"`
+	Market                   string     `json:"market" gorm:"size:255;not null;uniqueIndex:idx_derivative_contracts_key,priority:1"`
+	Symbol                   string     `json:"symbol" gorm:"size:100;not null;uniqueIndex:idx_derivative_contracts_key,priority:2"`
+	DerivativesExchangeID    *uint      `json:"derivatives_exchange_id" gorm:"index"` // FK to derivatives_exchanges(id), nil when no exchange has the market's name
+	IndexID                  *string    `json:"index_id" gorm:"size:50;index"`
+	ContractType             string     `json:"contract_type" gorm:"size:20;not null"`
+	Price                    *float64   `json:"price"`
+	PricePercentageChange24h *float64   `json:"price_percentage_change_24h" gorm:"column:price_percentage_change_24h"`
+	Index                    *float64   `json:"index"`
+	Basis                    *float64   `json:"basis"`
+	Spread                   *float64   `json:"spread"`
+	FundingRate              *float64   `json:"funding_rate"`
+	OpenInterestUSD          *float64   `json:"open_interest_usd" gorm:"column:open_interest_usd"`
+	Volume24hUSD             *float64   `json:"volume_24h_usd" gorm:"column:volume_24h_usd"`
+	LastTradedAt             *time.Time `json:"last_traded_at" gorm:"type:timestamptz"`
+	ExpiredAt                *time.Time `json:"expired_at" gorm:"type:timestamptz"` // futures only
+
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for the DerivativeContract model
+func (DerivativeContract) TableName() string {
+	return "derivative_contracts"
+}
+
+// DerivativeSnapshot is a point-in-time copy of a derivative contract's figures, appended on every derivatives sync
+type DerivativeSnapshot struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	ContractID      uint      `json:"contract_id" gorm:"not null;uniqueIndex:idx_derivative_snapshots_key,priority:1"` // FK to derivative_contracts(id)
+	CapturedAt      time.Time `json:"captured_at" gorm:"type:timestamptz;not null;uniqueIndex:idx_derivative_snapshots_key,priority:2"`
+	Price           *float64  `json:"price"`
+	Index           *float64  `json:"index"`
+	Basis           *float64  `json:"basis"`
+	Spread          *float64  `json:"spread"`
+	FundingRate     *float64  `json:"funding_rate"`
+	OpenInterestUSD *float64  `json:"open_interest_usd" gorm:"column:open_interest_usd"`
+	Volume24hUSD    *float64  `json:"volume_24h_usd" gorm:"column:volume_24h_usd"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the DerivativeSnapshot model
+func (DerivativeSnapshot) TableName() string {
+	return "derivative_snapshots"
+}
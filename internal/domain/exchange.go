@@ -8,20 +8,20 @@ import (
 
 // Exchange represents an exchange from CoinGecko
 type Exchange struct {
-	ID                          uint           `gorm:"primaryKey"`
-	CoingeckoID                 string         `gorm:"uniqueIndex;size:100;not null"`
-	Name                        string         `gorm:"size:255;not null"`
-	YearEstablished             *int           `gorm:"column:year_established"`
-	Country                     *string        `gorm:"size:100"`
-	Description                 *string        `gorm:"type:text"`
-	URL                         *string        `gorm:"size:500"`
-	Image                       *string        `gorm:"size:500"`
-	HasTradingIncentive         *bool          `gorm:"column:has_trading_incentive"`
-	TrustScore                  *int           `gorm:"column:trust_score"`
-	TrustScoreRank              *int           `gorm:"column:trust_score_rank"`
-	TradeVolume24hBTC           *float64       `gorm:"column:trade_volume_24h_btc"`
-	TradeVolume24hBTCNormalized *float64       `gorm:"column:trade_volume_24h_btc_normalized"`
-	CreatedAt                   time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt                   time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt                   gorm.DeletedAt `gorm:"index"`
+	ID                          uint           `json:"id" gorm:"primaryKey"`
+	CoingeckoID                 string         `json:"coingecko_id" gorm:"uniqueIndex;size:100;not null"`
+	Name                        string         `json:"name" gorm:"size:255;not null"`
+	YearEstablished             *int           `json:"year_established" gorm:"column:year_established"`
+	Country                     *string        `json:"country" gorm:"size:100"`
+	Description                 *string        `json:"description" gorm:"type:text"`
+	URL                         *string        `json:"url" gorm:"size:500"`
+	Image                       *string        `json:"image" gorm:"size:500"`
+	HasTradingIncentive         *bool          `json:"has_trading_incentive" gorm:"column:has_trading_incentive"`
+	TrustScore                  *int           `json:"trust_score" gorm:"column:trust_score"`
+	TrustScoreRank              *int           `json:"trust_score_rank" gorm:"column:trust_score_rank"`
+	TradeVolume24hBTC           *float64       `json:"trade_volume_24h_btc" gorm:"column:trade_volume_24h_btc"`
+	TradeVolume24hBTCNormalized *float64       `json:"trade_volume_24h_btc_normalized" gorm:"column:trade_volume_24h_btc_normalized"`
+	CreatedAt                   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt                   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt                   gorm.DeletedAt `json:"-" gorm:"index"`
 }
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ExchangeDetail stores the /exchanges/{id} payload of an exchange.
+// The payload also carries the exchange's top 100 tickers; the full list is normalized into coin_market_data.
+type ExchangeDetail struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	ExchangeID  uint   `json:"exchange_id" gorm:"not null;uniqueIndex"` // FK to exchanges(id)
+	CoingeckoID string `json:"coingecko_id" gorm:"size:100;not null"`
+	RawJSON     []byte `json:"-" gorm:"type:jsonb"`
+
+	// Selected denormalized fields for quick access
+	Centralized   *bool   `json:"centralized"`
+	PublicNotice  *string `json:"public_notice" gorm:"type:text"`
+	AlertNotice   *string `json:"alert_notice" gorm:"type:text"`
+	FacebookURL   *string `json:"facebook_url" gorm:"size:500"`
+	RedditURL     *string `json:"reddit_url" gorm:"size:500"`
+	TelegramURL   *string `json:"telegram_url" gorm:"size:500"`
+	SlackURL      *string `json:"slack_url" gorm:"size:500"`
+	OtherURL1     *string `json:"other_url_1" gorm:"column:other_url_1;size:500"`
+	OtherURL2     *string `json:"other_url_2" gorm:"column:other_url_2;size:500"`
+	TwitterHandle *string `json:"twitter_handle" gorm:"size:100"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+)
+
+// Types of units returned by /exchange_rates
+const (
+	ExchangeRateTypeFiat      = "fiat"
+	ExchangeRateTypeCrypto    = "crypto"
+	ExchangeRateTypeCommodity = "commodity"
+)
+
+// ExchangeRate is the BTC exchange rate of one unit at the time it was captured.
+// Value is how many of the unit one BTC is worth, so any two units of a snapshot convert through BTC.
+type ExchangeRate struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	CapturedAt time.Time `json:"captured_at" gorm:"type:timestamptz;not null;uniqueIndex:idx_exchange_rates_key,priority:1"`
+	Currency   string    `json:"currency" gorm:"size:20;not null;uniqueIndex:idx_exchange_rates_key,priority:2;index"` // key in /exchange_rates, e.g. usd
+	Name       string    `json:"name" gorm:"size:100"`
+	Unit       string    `json:"unit" gorm:"size:20"`
+	Type       string    `json:"type" gorm:"size:20"`
+	Value      float64   `json:"value" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the ExchangeRate model
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
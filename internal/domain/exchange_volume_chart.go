@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+)
+
+// ExchangeVolumePoint is one day of an exchange's /exchanges/{id}/volume_chart history.
+// Timestamp is UTC midnight of the day; VolumeBTC is the exchange's 24h trading volume in BTC at that time.
+type ExchangeVolumePoint struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	ExchangeID uint      `json:"exchange_id" gorm:"not null;uniqueIndex:idx_exchange_volume_points_key,priority:1"`
+	Timestamp  time.Time `json:"timestamp" gorm:"type:timestamptz;not null;uniqueIndex:idx_exchange_volume_points_key,priority:2"`
+	VolumeBTC  float64   `json:"volume_btc" gorm:"column:volume_btc;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the ExchangeVolumePoint model
+func (ExchangeVolumePoint) TableName() string {
+	return "exchange_volume_points"
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+)
+
+// GlobalSnapshot is market-wide data from /global and /global/decentralized_finance_defi at one point in time.
+// Per-currency figures are keyed by lowercase currency code; the USD and dominance figures are copied out for querying.
+type GlobalSnapshot struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	CapturedAt time.Time `json:"captured_at" gorm:"type:timestamptz;not null;uniqueIndex"`
+
+	ActiveCryptocurrencies          *int               `json:"active_cryptocurrencies"`
+	Markets                         *int               `json:"markets"`
+	TotalMarketCap                  map[string]float64 `json:"total_market_cap" gorm:"type:jsonb;serializer:json"`
+	TotalVolume                     map[string]float64 `json:"total_volume" gorm:"type:jsonb;serializer:json"`
+	MarketCapPercentage             map[string]float64 `json:"market_cap_percentage" gorm:"type:jsonb;serializer:json"`
+	TotalMarketCapUSD               *float64           `json:"total_market_cap_usd" gorm:"column:total_market_cap_usd"`
+	TotalVolumeUSD                  *float64           `json:"total_volume_usd" gorm:"column:total_volume_usd"`
+	BTCDominance                    *float64           `json:"btc_dominance" gorm:"column:btc_dominance"`
+	ETHDominance                    *float64           `json:"eth_dominance" gorm:"column:eth_dominance"`
+	MarketCapChangePercentage24hUSD *float64           `json:"market_cap_change_percentage_24h_usd" gorm:"column:market_cap_change_percentage_24h_usd"`
+	SourceUpdatedAt                 *time.Time         `json:"source_updated_at" gorm:"type:timestamptz"` // /global updated_at
+
+	// DeFi figures, nil when /global/decentralized_finance_defi could not be fetched
+	DefiMarketCap        *float64 `json:"defi_market_cap" gorm:"column:defi_market_cap"`
+	EthMarketCap         *float64 `json:"eth_market_cap" gorm:"column:eth_market_cap"`
+	DefiToEthRatio       *float64 `json:"defi_to_eth_ratio" gorm:"column:defi_to_eth_ratio"`
+	DefiVolume24h        *float64 `json:"defi_volume_24h" gorm:"column:defi_volume_24h"`
+	DefiDominance        *float64 `json:"defi_dominance" gorm:"column:defi_dominance"`
+	TopDefiCoinName      *string  `json:"top_defi_coin_name" gorm:"size:255"`
+	TopDefiCoinDominance *float64 `json:"top_defi_coin_dominance" gorm:"column:top_defi_coin_dominance"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
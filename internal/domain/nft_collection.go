@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NFTCollection represents an NFT collection from /nfts/list.
+// The descriptive and latest market fields come from its /nfts/{id} payload and stay empty until that was fetched.
+type NFTCollection struct {
+	ID              uint    `json:"id" gorm:"primaryKey"`
+	CoingeckoID     string  `json:"coingecko_id" gorm:"uniqueIndex;size:255;not null"`
+	AssetPlatformID *string `json:"asset_platform_id" gorm:"type:varchar(50);index:idx_nft_collections_contract,priority:1"` // references asset_platforms(id)
+	ContractAddress *string `json:"contract_address" gorm:"type:varchar(255);index:idx_nft_collections_contract,priority:2"`
+	Name            string  `json:"name" gorm:"size:255;not null"`
+	Symbol          *string `json:"symbol" gorm:"size:100"`
+
+	// From /nfts/{id}
+	RawJSON                 []byte     `json:"-" gorm:"type:jsonb"`
+	Description             *string    `json:"description" gorm:"type:text"`
+	Image                   *string    `json:"image" gorm:"size:500"`
+	NativeCurrency          *string    `json:"native_currency" gorm:"size:50"`
+	NativeCurrencySymbol    *string    `json:"native_currency_symbol" gorm:"size:20"`
+	FloorPriceUSD           *float64   `json:"floor_price_usd" gorm:"column:floor_price_usd"`
+	MarketCapUSD            *float64   `json:"market_cap_usd" gorm:"column:market_cap_usd"`
+	Volume24hUSD            *float64   `json:"volume_24h_usd" gorm:"column:volume_24h_usd"`
+	NumberOfUniqueAddresses *int       `json:"number_of_unique_addresses"`
+	TotalSupply             *float64   `json:"total_supply"`
+	DetailFetchedAt         *time.Time `json:"detail_fetched_at" gorm:"type:timestamptz"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for the NFTCollection model
+func (NFTCollection) TableName() string {
+	return "nft_collections"
+}
+
+// NFTSnapshot records the floor price, market cap and volume of an NFT collection at one point in time.
+// Native figures are in the collection's NativeCurrency, e.g. ETH for most Ethereum collections.
+type NFTSnapshot struct {
+	ID                               uint      `json:"id" gorm:"primaryKey"`
+	CollectionID                     uint      `json:"collection_id" gorm:"not null;uniqueIndex:idx_nft_snapshots_key,priority:1"` // FK to nft_collections(id)
+	CapturedAt                       time.Time `json:"captured_at" gorm:"type:timestamptz;not null;uniqueIndex:idx_nft_snapshots_key,priority:2"`
+	NativeCurrency                   *string   `json:"native_currency" gorm:"size:50"`
+	FloorPriceNative                 *float64  `json:"floor_price_native"`
+	FloorPriceUSD                    *float64  `json:"floor_price_usd" gorm:"column:floor_price_usd"`
+	MarketCapNative                  *float64  `json:"market_cap_native"`
+	MarketCapUSD                     *float64  `json:"market_cap_usd" gorm:"column:market_cap_usd"`
+	Volume24hNative                  *float64  `json:"volume_24h_native" gorm:"column:volume_24h_native"`
+	Volume24hUSD                     *float64  `json:"volume_24h_usd" gorm:"column:volume_24h_usd"`
+	FloorPriceChangePercentage24hUSD *float64  `json:"floor_price_change_percentage_24h_usd" gorm:"column:floor_price_change_percentage_24h_usd"`
+	NumberOfUniqueAddresses          *int      `json:"number_of_unique_addresses"`
+	CreatedAt                        time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the NFTSnapshot model
+func (NFTSnapshot) TableName() string {
+	return "nft_snapshots"
+}
@@ -0,0 +1,34 @@
+package domain
+
+// Default and maximum page sizes for list queries, mirroring CoinGecko's limits
+const (
+	DefaultPerPage = 100
+	MaxPerPage     = 250
+)
+
+// ListOptions describes pagination and ordering for list queries
+type ListOptions struct {
+	Page     int
+	PerPage  int
+	SortBy   string
+	SortDesc bool
+}
+
+// Normalize clamps page and per-page values into their valid ranges
+func (o ListOptions) Normalize() ListOptions {
+	if o.Page < 1 {
+		o.Page = 1
+	}
+	if o.PerPage < 1 {
+		o.PerPage = DefaultPerPage
+	}
+	if o.PerPage > MaxPerPage {
+		o.PerPage = MaxPerPage
+	}
+	return o
+}
+
+// Offset returns the number of rows to skip for the requested page
+func (o ListOptions) Offset() int {
+	return (o.Page - 1) * o.PerPage
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"time"
+)
+
+// SupportedVsCurrency is a quote currency accepted by CoinGecko's vs_currency parameters
+type SupportedVsCurrency struct {
+	Code      string    `json:"code" gorm:"type:varchar(20);primaryKey"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+)
+
+// SyncCheckpoint records how far a long-running sync got so the next run can resume from there.
+// Page is 0 once CoingeckoID has been fully processed, otherwise the first ticker page still to fetch.
+type SyncCheckpoint struct {
+	Kind        string    `json:"kind" gorm:"type:varchar(50);primaryKey"`
+	CoingeckoID string    `json:"coingecko_id" gorm:"type:varchar(100);not null"`
+	Page        int       `json:"page" gorm:"not null;default:0"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
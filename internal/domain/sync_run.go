@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+)
+
+// Sync kinds recorded in the sync run journal
+const (
+	SyncKindAssetPlatforms       = "asset_platforms"
+	SyncKindCoinCategories       = "coin_categories"
+	SyncKindCoinCategoriesMarket = "coin_categories_market"
+	SyncKindExchanges            = "exchanges"
+	SyncKindExchangesData        = "exchanges_data"
+	SyncKindCoins                = "coins"
+	SyncKindCoinList             = "coin_list"
+	SyncKindCoinsData            = "coins_data"
+	SyncKindCoinMarketData       = "coin_market_data"
+	SyncKindOHLC                 = "ohlc"
+	SyncKindBackfillHistory      = "backfill_history"
+	SyncKindVsCurrencies         = "supported_vs_currencies"
+	SyncKindGlobal               = "global"
+	SyncKindTrending             = "trending"
+	SyncKindNFTs                 = "nfts"
+	SyncKindNFTsData             = "nfts_data"
+	SyncKindDerivatives          = "derivatives"
+	SyncKindDerivativesExchanges = "derivatives_exchanges"
+	SyncKindExchangeRates        = "exchange_rates"
+)
+
+// Sync run statuses
+const (
+	SyncStatusRunning     = "running"
+	SyncStatusSucceeded   = "succeeded"
+	SyncStatusPartial     = "partial" // stopped early on purpose; the next run resumes
+	SyncStatusFailed      = "failed"
+	SyncStatusInterrupted = "interrupted"
+)
+
+// SyncRun is one entry of the sync run journal, written at the start and end of every sync
+type SyncRun struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	Kind         string     `json:"kind" gorm:"type:varchar(50);not null;index:idx_sync_runs_kind_started,priority:1"`
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;index"`
+	StartedAt    time.Time  `json:"started_at" gorm:"type:timestamptz;not null;index:idx_sync_runs_kind_started,priority:2;index"`
+	FinishedAt   *time.Time `json:"finished_at" gorm:"type:timestamptz"`
+	PagesFetched int64      `json:"pages_fetched" gorm:"not null;default:0"`
+	RowsInserted int64      `json:"rows_inserted" gorm:"not null;default:0"`
+	RowsUpdated  int64      `json:"rows_updated" gorm:"not null;default:0"`
+	RowsSkipped  int64      `json:"rows_skipped" gorm:"not null;default:0"`
+	APICalls     int64      `json:"api_calls" gorm:"column:api_calls;not null;default:0"`
+	Error        *string    `json:"error" gorm:"type:text"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Duration returns how long the run took, or has been running so far
+func (r SyncRun) Duration() time.Duration {
+	if r.FinishedAt == nil {
+		return time.Since(r.StartedAt)
+	}
+	return r.FinishedAt.Sub(r.StartedAt)
+}
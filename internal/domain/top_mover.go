@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+)
+
+// Directions of a top mover
+const (
+	TopMoverGainer = "gainer"
+	TopMoverLoser  = "loser"
+)
+
+// TopMover is one entry of the /coins/top_gainers_losers lists at the time it was captured.
+// Rank is the 1-based position in its list; figures are in VsCurrency over the last 24 hours.
+type TopMover struct {
+	ID                       uint      `json:"id" gorm:"primaryKey"`
+	CapturedAt               time.Time `json:"captured_at" gorm:"type:timestamptz;not null;uniqueIndex:idx_top_movers_key,priority:1"`
+	Direction                string    `json:"direction" gorm:"size:10;not null;uniqueIndex:idx_top_movers_key,priority:2"`
+	Rank                     int       `json:"rank" gorm:"not null;uniqueIndex:idx_top_movers_key,priority:3"`
+	CoingeckoID              string    `json:"coingecko_id" gorm:"size:255;not null;index"`
+	Symbol                   string    `json:"symbol" gorm:"size:50"`
+	Name                     string    `json:"name" gorm:"size:255"`
+	MarketCapRank            *int      `json:"market_cap_rank"`
+	VsCurrency               string    `json:"vs_currency" gorm:"size:20;not null"`
+	Price                    *float64  `json:"price"`
+	Volume24h                *float64  `json:"volume_24h" gorm:"column:volume_24h"`
+	PriceChangePercentage24h *float64  `json:"price_change_percentage_24h" gorm:"column:price_change_percentage_24h"`
+	CreatedAt                time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the TopMover model
+func (TopMover) TableName() string {
+	return "top_movers"
+}
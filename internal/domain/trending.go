@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+)
+
+// Kinds of trending lists returned by /search/trending
+const (
+	TrendingKindCoin     = "coin"
+	TrendingKindNFT      = "nft"
+	TrendingKindCategory = "category"
+)
+
+// TrendingItem is one entry of a /search/trending list at the time it was captured.
+// Rank is the 1-based position in its list; CoingeckoID is the coin or NFT id, or the slug of a category.
+type TrendingItem struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	CapturedAt    time.Time `json:"captured_at" gorm:"type:timestamptz;not null;uniqueIndex:idx_trending_items_key,priority:1"`
+	Kind          string    `json:"kind" gorm:"size:20;not null;uniqueIndex:idx_trending_items_key,priority:2"`
+	Rank          int       `json:"rank" gorm:"not null;uniqueIndex:idx_trending_items_key,priority:3"`
+	CoingeckoID   string    `json:"coingecko_id" gorm:"size:255;not null;index"`
+	Symbol        string    `json:"symbol" gorm:"size:50"`
+	Name          string    `json:"name" gorm:"size:255"`
+	MarketCapRank *int      `json:"market_cap_rank"`                   // coins only
+	PriceBTC      *float64  `json:"price_btc" gorm:"column:price_btc"` // coins only
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the TrendingItem model
+func (TrendingItem) TableName() string {
+	return "trending_items"
+}
+
+// TrendingSummary aggregates the trending snapshots of one entry over a period
+type TrendingSummary struct {
+	CoingeckoID string    `json:"coingecko_id"`
+	Symbol      string    `json:"symbol"`
+	Name        string    `json:"name"`
+	Snapshots   int       `json:"snapshots"` // how many captures listed it
+	BestRank    int       `json:"best_rank"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+)
+
+// assetPlatformSortFields lists the columns asset platforms can be sorted by
+var assetPlatformSortFields = sortFields{
+	"id":   false,
+	"name": false,
+}
+
+// AssetPlatformHandler serves asset platform data from the local database
+type AssetPlatformHandler struct {
+	repo         domain.AssetPlatformRepository
+	contractRepo repository.CoinContractRepository
+	nftRepo      repository.NFTCollectionRepository
+}
+
+// NewAssetPlatformHandler creates a new asset platform handler
+func NewAssetPlatformHandler(
+	repo domain.AssetPlatformRepository,
+	contractRepo repository.CoinContractRepository,
+	nftRepo repository.NFTCollectionRepository,
+) *AssetPlatformHandler {
+	return &AssetPlatformHandler{repo: repo, contractRepo: contractRepo, nftRepo: nftRepo}
+}
+
+// ListAssetPlatforms handles GET /asset-platforms
+func (h *AssetPlatformHandler) ListAssetPlatforms(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, assetPlatformSortFields, "id")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	platforms, total, err := h.repo.List(opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: platforms, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetContractCoin handles GET /asset-platforms/{id}/contracts/{address}
+func (h *AssetPlatformHandler) GetContractCoin(w http.ResponseWriter, r *http.Request) {
+	platformID, address := r.PathValue("id"), r.PathValue("address")
+
+	coin, err := h.contractRepo.GetCoinByAddress(platformID, address)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if coin == nil {
+		writeError(w, http.StatusNotFound, "contract not found: "+platformID+"/"+address)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, coin)
+}
+
+// ListPlatformNFTs handles GET /asset-platforms/{id}/nfts
+func (h *AssetPlatformHandler) ListPlatformNFTs(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, nftSortFields, "market_cap_usd")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collections, total, err := h.nftRepo.ListByPlatform(r.PathValue("id"), opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: collections, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+)
+
+// coinCategorySortFields lists the columns coin categories can be sorted by
+var coinCategorySortFields = sortFields{
+	"name":                  false,
+	"market_cap":            true,
+	"market_cap_change_24h": true,
+	"volume_24h":            true,
+}
+
+// CoinCategoryHandler serves coin category data from the local database
+type CoinCategoryHandler struct {
+	repo           domain.CoinCategoryRepository
+	membershipRepo repository.CoinCategoryMembershipRepository
+	snapshotRepo   repository.CategorySnapshotRepository
+}
+
+// NewCoinCategoryHandler creates a new coin category handler
+func NewCoinCategoryHandler(
+	repo domain.CoinCategoryRepository,
+	membershipRepo repository.CoinCategoryMembershipRepository,
+	snapshotRepo repository.CategorySnapshotRepository,
+) *CoinCategoryHandler {
+	return &CoinCategoryHandler{repo: repo, membershipRepo: membershipRepo, snapshotRepo: snapshotRepo}
+}
+
+// ListCoinCategories handles GET /categories
+func (h *CoinCategoryHandler) ListCoinCategories(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, coinCategorySortFields, "name")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	categories, total, err := h.repo.List(opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: categories, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// ListCategoryCoins handles GET /categories/{id}/coins
+func (h *CoinCategoryHandler) ListCategoryCoins(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, coinSortFields, "market_cap_rank")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	category, ok := h.lookupCategory(w, r)
+	if !ok {
+		return
+	}
+
+	coins, total, err := h.membershipRepo.ListCoinsByCategory(category.CoingeckoID, opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: coins, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetCategoryHistory handles GET /categories/{id}/history; days selects how far back to go (default 30)
+func (h *CoinCategoryHandler) GetCategoryHistory(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid days: "+v)
+			return
+		}
+		days = n
+	}
+
+	category, ok := h.lookupCategory(w, r)
+	if !ok {
+		return
+	}
+
+	to := time.Now().UTC()
+	snapshots, err := h.snapshotRepo.GetRange(category.ID, to.AddDate(0, 0, -days), to)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshots)
+}
+
+// lookupCategory resolves the {id} path value to a stored coin category, writing a 404 if it is unknown
+func (h *CoinCategoryHandler) lookupCategory(w http.ResponseWriter, r *http.Request) (*domain.CoinCategory, bool) {
+	id := r.PathValue("id")
+
+	category, err := h.repo.GetByCoingeckoID(id)
+	if err != nil {
+		writeInternalError(w, err)
+		return nil, false
+	}
+	if category == nil {
+		writeError(w, http.StatusNotFound, "category not found: "+id)
+		return nil, false
+	}
+	return category, true
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+)
+
+// coinSortFields lists the columns coins can be sorted by
+var coinSortFields = sortFields{
+	"market_cap_rank": false,
+	"total_volume":    true,
+}
+
+// CoinHandler serves coin data from the local database
+type CoinHandler struct {
+	coinRepo       repository.CoinRepository
+	coinDetailRepo repository.CoinDetailRepository
+	marketDataRepo repository.CoinMarketDataRepository
+	coinQuoteRepo  repository.CoinQuoteRepository
+	membershipRepo repository.CoinCategoryMembershipRepository
+	contractRepo   repository.CoinContractRepository
+}
+
+// NewCoinHandler creates a new coin handler
+func NewCoinHandler(
+	coinRepo repository.CoinRepository,
+	coinDetailRepo repository.CoinDetailRepository,
+	marketDataRepo repository.CoinMarketDataRepository,
+	coinQuoteRepo repository.CoinQuoteRepository,
+	membershipRepo repository.CoinCategoryMembershipRepository,
+	contractRepo repository.CoinContractRepository,
+) *CoinHandler {
+	return &CoinHandler{
+		coinRepo:       coinRepo,
+		coinDetailRepo: coinDetailRepo,
+		marketDataRepo: marketDataRepo,
+		coinQuoteRepo:  coinQuoteRepo,
+		membershipRepo: membershipRepo,
+		contractRepo:   contractRepo,
+	}
+}
+
+// coinDetailResponse is a coin together with its raw CoinGecko detail payload
+type coinDetailResponse struct {
+	domain.Coin
+	Detail json.RawMessage `json:"detail,omitempty"`
+}
+
+// ListCoins handles GET /coins; vs_currency selects the quote currency (default usd)
+func (h *CoinHandler) ListCoins(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, coinSortFields, "market_cap_rank")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	coins, total, err := h.coinRepo.List(opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if !applyVsCurrency(w, h.coinQuoteRepo, r.URL.Query().Get("vs_currency"), coins) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: coins, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetCoin handles GET /coins/{id}; vs_currency selects the quote currency (default usd)
+func (h *CoinHandler) GetCoin(w http.ResponseWriter, r *http.Request) {
+	coin, ok := h.lookupCoin(w, r)
+	if !ok {
+		return
+	}
+	quoted := []domain.Coin{*coin}
+	if !applyVsCurrency(w, h.coinQuoteRepo, r.URL.Query().Get("vs_currency"), quoted) {
+		return
+	}
+	coin = &quoted[0]
+
+	detail, err := h.coinDetailRepo.GetByCoinID(coin.ID)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	response := coinDetailResponse{Coin: *coin}
+	if detail != nil {
+		response.Detail = detail.RawJSON
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetCoinTickers handles GET /coins/{id}/tickers
+func (h *CoinHandler) GetCoinTickers(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, tickerSortFields, "converted_volume_usd")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	coin, ok := h.lookupCoin(w, r)
+	if !ok {
+		return
+	}
+
+	tickers, total, err := h.marketDataRepo.ListByCoinID(coin.ID, opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: tickers, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetCoinCategories handles GET /coins/{id}/categories
+func (h *CoinHandler) GetCoinCategories(w http.ResponseWriter, r *http.Request) {
+	coin, ok := h.lookupCoin(w, r)
+	if !ok {
+		return
+	}
+
+	categories, err := h.membershipRepo.GetCategoriesByCoin(coin.ID)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, categories)
+}
+
+// GetCoinContracts handles GET /coins/{id}/contracts
+func (h *CoinHandler) GetCoinContracts(w http.ResponseWriter, r *http.Request) {
+	coin, ok := h.lookupCoin(w, r)
+	if !ok {
+		return
+	}
+
+	contracts, err := h.contractRepo.GetByCoinID(coin.ID)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, contracts)
+}
+
+// lookupCoin resolves the {id} path value to a stored coin, writing a 404 if it is unknown
+func (h *CoinHandler) lookupCoin(w http.ResponseWriter, r *http.Request) (*domain.Coin, bool) {
+	id := r.PathValue("id")
+
+	coin, err := h.coinRepo.GetByCoingeckoID(id)
+	if err != nil {
+		writeInternalError(w, err)
+		return nil, false
+	}
+	if coin == nil {
+		writeError(w, http.StatusNotFound, "coin not found: "+id)
+		return nil, false
+	}
+	return coin, true
+}
+
+// applyVsCurrency switches the price figures of coins to the requested quote currency, writing an error response
+// and returning false when it cannot. USD figures live on the coins themselves; other currencies come from
+// coin_quotes and are only accepted once they have been synced. Coins without a quote get null figures.
+func applyVsCurrency(w http.ResponseWriter, quoteRepo repository.CoinQuoteRepository, vsCurrency string, coins []domain.Coin) bool {
+	vsCurrency = strings.ToLower(vsCurrency)
+	if vsCurrency == "" || vsCurrency == "usd" {
+		return true
+	}
+
+	currencies, err := quoteRepo.GetCurrencies()
+	if err != nil {
+		writeInternalError(w, err)
+		return false
+	}
+	if !slices.Contains(currencies, vsCurrency) {
+		writeError(w, http.StatusBadRequest, "invalid vs_currency")
+		return false
+	}
+
+	ids := make([]uint, len(coins))
+	for i, coin := range coins {
+		ids[i] = coin.ID
+	}
+	quotes, err := quoteRepo.GetByCoinIDs(ids, vsCurrency)
+	if err != nil {
+		writeInternalError(w, err)
+		return false
+	}
+	for i := range coins {
+		quotes[coins[i].ID].ApplyTo(&coins[i])
+	}
+	return true
+}
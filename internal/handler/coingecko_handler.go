@@ -0,0 +1,443 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+)
+
+// coinGeckoTimeFormat matches the millisecond UTC timestamps used by CoinGecko
+const coinGeckoTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// coinGeckoMarketOrders maps CoinGecko /coins/markets order values to list options
+var coinGeckoMarketOrders = map[string]domain.ListOptions{
+	"market_cap_desc": {SortBy: "market_cap", SortDesc: true},
+	"market_cap_asc":  {SortBy: "market_cap"},
+	"volume_desc":     {SortBy: "total_volume", SortDesc: true},
+	"volume_asc":      {SortBy: "total_volume"},
+	"id_asc":          {SortBy: "coingecko_id"},
+	"id_desc":         {SortBy: "coingecko_id", SortDesc: true},
+}
+
+// CoinGeckoHandler answers a subset of CoinGecko v3 endpoints from the local database
+type CoinGeckoHandler struct {
+	coinRepo          repository.CoinRepository
+	coinDetailRepo    repository.CoinDetailRepository
+	coinTickerRepo    repository.CoinTickerRepository
+	coinQuoteRepo     repository.CoinQuoteRepository
+	exchangeRepo      repository.ExchangeRepository
+	coinCategoryRepo  domain.CoinCategoryRepository
+	assetPlatformRepo domain.AssetPlatformRepository
+}
+
+// NewCoinGeckoHandler creates a new CoinGecko-compatible handler
+func NewCoinGeckoHandler(
+	coinRepo repository.CoinRepository,
+	coinDetailRepo repository.CoinDetailRepository,
+	coinTickerRepo repository.CoinTickerRepository,
+	coinQuoteRepo repository.CoinQuoteRepository,
+	exchangeRepo repository.ExchangeRepository,
+	coinCategoryRepo domain.CoinCategoryRepository,
+	assetPlatformRepo domain.AssetPlatformRepository,
+) *CoinGeckoHandler {
+	return &CoinGeckoHandler{
+		coinRepo:          coinRepo,
+		coinDetailRepo:    coinDetailRepo,
+		coinTickerRepo:    coinTickerRepo,
+		coinQuoteRepo:     coinQuoteRepo,
+		exchangeRepo:      exchangeRepo,
+		coinCategoryRepo:  coinCategoryRepo,
+		assetPlatformRepo: assetPlatformRepo,
+	}
+}
+
+// coinMarketResponse mirrors an element of CoinGecko's /coins/markets response
+type coinMarketResponse struct {
+	ID                           string          `json:"id"`
+	Symbol                       string          `json:"symbol"`
+	Name                         string          `json:"name"`
+	Image                        *string         `json:"image"`
+	CurrentPrice                 *float64        `json:"current_price"`
+	MarketCap                    *float64        `json:"market_cap"`
+	MarketCapRank                *int            `json:"market_cap_rank"`
+	FullyDilutedValuation        *float64        `json:"fully_diluted_valuation"`
+	TotalVolume                  *float64        `json:"total_volume"`
+	High24h                      *float64        `json:"high_24h"`
+	Low24h                       *float64        `json:"low_24h"`
+	PriceChange24h               *float64        `json:"price_change_24h"`
+	PriceChangePercentage24h     *float64        `json:"price_change_percentage_24h"`
+	MarketCapChange24h           *float64        `json:"market_cap_change_24h"`
+	MarketCapChangePercentage24h *float64        `json:"market_cap_change_percentage_24h"`
+	CirculatingSupply            *float64        `json:"circulating_supply"`
+	TotalSupply                  *float64        `json:"total_supply"`
+	MaxSupply                    *float64        `json:"max_supply"`
+	Ath                          *float64        `json:"ath"`
+	AthChangePercentage          *float64        `json:"ath_change_percentage"`
+	AthDate                      *string         `json:"ath_date"`
+	Atl                          *float64        `json:"atl"`
+	AtlChangePercentage          *float64        `json:"atl_change_percentage"`
+	AtlDate                      *string         `json:"atl_date"`
+	ROI                          *domain.CoinROI `json:"roi"`
+	LastUpdated                  *string         `json:"last_updated"`
+}
+
+// coinListResponse mirrors an element of CoinGecko's /coins/list response
+type coinListResponse struct {
+	ID        string          `json:"id"`
+	Symbol    string          `json:"symbol"`
+	Name      string          `json:"name"`
+	Platforms json.RawMessage `json:"platforms,omitempty"`
+}
+
+// exchangeResponse mirrors an element of CoinGecko's /exchanges response
+type exchangeResponse struct {
+	ID                          string   `json:"id"`
+	Name                        string   `json:"name"`
+	YearEstablished             *int     `json:"year_established"`
+	Country                     *string  `json:"country"`
+	Description                 *string  `json:"description"`
+	URL                         *string  `json:"url"`
+	Image                       *string  `json:"image"`
+	HasTradingIncentive         *bool    `json:"has_trading_incentive"`
+	TrustScore                  *int     `json:"trust_score"`
+	TrustScoreRank              *int     `json:"trust_score_rank"`
+	TradeVolume24hBTC           *float64 `json:"trade_volume_24h_btc"`
+	TradeVolume24hBTCNormalized *float64 `json:"trade_volume_24h_btc_normalized"`
+}
+
+// assetPlatformResponse mirrors an element of CoinGecko's /asset_platforms response
+type assetPlatformResponse struct {
+	ID              string  `json:"id"`
+	ChainIdentifier *int64  `json:"chain_identifier"`
+	Name            string  `json:"name"`
+	ShortName       *string `json:"shortname"`
+	NativeCoinID    *string `json:"native_coin_id"`
+}
+
+// coinCategoryResponse mirrors an element of CoinGecko's /coins/categories/list response
+type coinCategoryResponse struct {
+	CategoryID string `json:"category_id"`
+	Name       string `json:"name"`
+}
+
+// Ping handles GET /api/v3/ping
+func (h *CoinGeckoHandler) Ping(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"gecko_says": "(V3) To the Moon!"})
+}
+
+// CoinsMarkets handles GET /api/v3/coins/markets
+func (h *CoinGeckoHandler) CoinsMarkets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	vsCurrency := query.Get("vs_currency")
+	if vsCurrency == "" {
+		writeError(w, http.StatusBadRequest, "Missing parameter vs_currency")
+		return
+	}
+
+	order := query.Get("order")
+	if order == "" {
+		order = "market_cap_desc"
+	}
+	opts, ok := coinGeckoMarketOrders[order]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid order")
+		return
+	}
+
+	var err error
+	if opts.Page, err = parseCoinGeckoInt(query.Get("page"), 1); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid page")
+		return
+	}
+	if opts.PerPage, err = parseCoinGeckoInt(query.Get("per_page"), domain.DefaultPerPage); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid per_page")
+		return
+	}
+	opts = opts.Normalize()
+
+	var coins []domain.Coin
+	if ids := splitCoinGeckoList(query.Get("ids")); len(ids) > 0 {
+		coins, err = h.coinRepo.ListByCoingeckoIDs(ids, opts)
+	} else {
+		coins, _, err = h.coinRepo.List(opts)
+	}
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if !applyVsCurrency(w, h.coinQuoteRepo, vsCurrency, coins) {
+		return
+	}
+
+	response := make([]coinMarketResponse, len(coins))
+	for i, coin := range coins {
+		response[i] = coinMarketResponse{
+			ID:                           coin.CoingeckoID,
+			Symbol:                       coin.Symbol,
+			Name:                         coin.Name,
+			Image:                        coin.Image,
+			CurrentPrice:                 coin.CurrentPrice,
+			MarketCap:                    coin.MarketCap,
+			MarketCapRank:                coin.MarketCapRank,
+			FullyDilutedValuation:        coin.FullyDilutedValuation,
+			TotalVolume:                  coin.TotalVolume,
+			High24h:                      coin.High24h,
+			Low24h:                       coin.Low24h,
+			PriceChange24h:               coin.PriceChange24h,
+			PriceChangePercentage24h:     coin.PriceChangePercentage24h,
+			MarketCapChange24h:           coin.MarketCapChange24h,
+			MarketCapChangePercentage24h: coin.MarketCapChangePercentage24h,
+			CirculatingSupply:            coin.CirculatingSupply,
+			TotalSupply:                  coin.TotalSupply,
+			MaxSupply:                    coin.MaxSupply,
+			Ath:                          coin.Ath,
+			AthChangePercentage:          coin.AthChangePercentage,
+			AthDate:                      formatCoinGeckoTime(coin.AthDate),
+			Atl:                          coin.Atl,
+			AtlChangePercentage:          coin.AtlChangePercentage,
+			AtlDate:                      formatCoinGeckoTime(coin.AtlDate),
+			ROI:                          coin.ROI,
+			LastUpdated:                  formatCoinGeckoTime(coin.LastUpdated),
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CoinsList handles GET /api/v3/coins/list
+func (h *CoinGeckoHandler) CoinsList(w http.ResponseWriter, r *http.Request) {
+	coins, err := h.coinRepo.GetAll()
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	sort.Slice(coins, func(i, j int) bool { return coins[i].CoingeckoID < coins[j].CoingeckoID })
+
+	// Like CoinGecko, only active coins are listed unless status=inactive asks for the others
+	active := true
+	switch r.URL.Query().Get("status") {
+	case "", "active":
+	case "inactive":
+		active = false
+	default:
+		writeError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+	listed := coins[:0]
+	for _, coin := range coins {
+		if coin.Active == active {
+			listed = append(listed, coin)
+		}
+	}
+	coins = listed
+
+	includePlatform := r.URL.Query().Get("include_platform") == "true"
+	var platforms map[string][]byte
+	if includePlatform {
+		if platforms, err = h.coinDetailRepo.GetPlatforms(); err != nil {
+			writeInternalError(w, err)
+			return
+		}
+	}
+
+	response := make([]coinListResponse, len(coins))
+	for i, coin := range coins {
+		response[i] = coinListResponse{ID: coin.CoingeckoID, Symbol: coin.Symbol, Name: coin.Name}
+		if includePlatform {
+			response[i].Platforms = json.RawMessage("{}")
+			if raw, ok := platforms[coin.CoingeckoID]; ok {
+				response[i].Platforms = raw
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Coin handles GET /api/v3/coins/{id} by replaying the stored payload
+func (h *CoinGeckoHandler) Coin(w http.ResponseWriter, r *http.Request) {
+	coin, ok := h.lookupCoin(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.coinDetailRepo.GetByCoinID(coin.ID)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if detail == nil || len(detail.RawJSON) == 0 {
+		writeError(w, http.StatusNotFound, "coin not found")
+		return
+	}
+
+	writeRawJSON(w, detail.RawJSON)
+}
+
+// CoinTickers handles GET /api/v3/coins/{id}/tickers by replaying the stored page
+func (h *CoinGeckoHandler) CoinTickers(w http.ResponseWriter, r *http.Request) {
+	page, err := parseCoinGeckoInt(r.URL.Query().Get("page"), 1)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid page")
+		return
+	}
+
+	coin, ok := h.lookupCoin(w, r)
+	if !ok {
+		return
+	}
+
+	ticker, err := h.coinTickerRepo.GetByCoinIDAndPage(coin.ID, page)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if ticker == nil || len(ticker.RawJSON) == 0 {
+		// CoinGecko answers pages past the end with an empty tickers list
+		writeJSON(w, http.StatusOK, map[string]any{"name": coin.Name, "tickers": []any{}})
+		return
+	}
+
+	writeRawJSON(w, ticker.RawJSON)
+}
+
+// Exchanges handles GET /api/v3/exchanges
+func (h *CoinGeckoHandler) Exchanges(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	opts := domain.ListOptions{SortBy: "trust_score_rank"}
+	var err error
+	if opts.Page, err = parseCoinGeckoInt(query.Get("page"), 1); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid page")
+		return
+	}
+	if opts.PerPage, err = parseCoinGeckoInt(query.Get("per_page"), domain.DefaultPerPage); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid per_page")
+		return
+	}
+
+	exchanges, _, err := h.exchangeRepo.List(opts.Normalize())
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	response := make([]exchangeResponse, len(exchanges))
+	for i, exchange := range exchanges {
+		response[i] = exchangeResponse{
+			ID:                          exchange.CoingeckoID,
+			Name:                        exchange.Name,
+			YearEstablished:             exchange.YearEstablished,
+			Country:                     exchange.Country,
+			Description:                 exchange.Description,
+			URL:                         exchange.URL,
+			Image:                       exchange.Image,
+			HasTradingIncentive:         exchange.HasTradingIncentive,
+			TrustScore:                  exchange.TrustScore,
+			TrustScoreRank:              exchange.TrustScoreRank,
+			TradeVolume24hBTC:           exchange.TradeVolume24hBTC,
+			TradeVolume24hBTCNormalized: exchange.TradeVolume24hBTCNormalized,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// AssetPlatforms handles GET /api/v3/asset_platforms
+func (h *CoinGeckoHandler) AssetPlatforms(w http.ResponseWriter, r *http.Request) {
+	platforms, err := h.assetPlatformRepo.GetAll()
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	response := make([]assetPlatformResponse, len(platforms))
+	for i, platform := range platforms {
+		response[i] = assetPlatformResponse{
+			ID:              platform.ID,
+			ChainIdentifier: platform.ChainIdentifier,
+			Name:            platform.Name,
+			ShortName:       platform.ShortName,
+			NativeCoinID:    platform.NativeCoinID,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CoinCategoriesList handles GET /api/v3/coins/categories/list
+func (h *CoinGeckoHandler) CoinCategoriesList(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.coinCategoryRepo.GetAll()
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+
+	response := make([]coinCategoryResponse, len(categories))
+	for i, category := range categories {
+		response[i] = coinCategoryResponse{CategoryID: category.CoingeckoID, Name: category.Name}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// lookupCoin resolves the {id} path value to a stored coin, writing CoinGecko's 404 body if it is unknown
+func (h *CoinGeckoHandler) lookupCoin(w http.ResponseWriter, r *http.Request) (*domain.Coin, bool) {
+	coin, err := h.coinRepo.GetByCoingeckoID(r.PathValue("id"))
+	if err != nil {
+		writeInternalError(w, err)
+		return nil, false
+	}
+	if coin == nil {
+		writeError(w, http.StatusNotFound, "coin not found")
+		return nil, false
+	}
+	return coin, true
+}
+
+// writeRawJSON writes an already encoded JSON payload as-is
+func writeRawJSON(w http.ResponseWriter, payload []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(payload)
+}
+
+// parseCoinGeckoInt parses a positive integer query value, falling back to the default when empty
+func parseCoinGeckoInt(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// splitCoinGeckoList splits a comma-separated query value, dropping empty entries
+func splitCoinGeckoList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// formatCoinGeckoTime formats a timestamp the way CoinGecko does, keeping nulls
+func formatCoinGeckoTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(coinGeckoTimeFormat)
+	return &formatted
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"cgoffline/internal/service"
+)
+
+// ContractHandler resolves token contract addresses to coins
+type ContractHandler struct {
+	service service.ContractService
+}
+
+// NewContractHandler creates a new contract handler
+func NewContractHandler(contractService service.ContractService) *ContractHandler {
+	return &ContractHandler{service: contractService}
+}
+
+// ResolveContract handles GET /contracts/{platform_id}/{address}
+func (h *ContractHandler) ResolveContract(w http.ResponseWriter, r *http.Request) {
+	platformID, address := r.PathValue("platform_id"), r.PathValue("address")
+
+	resolution, err := h.service.ResolveContract(r.Context(), platformID, address)
+	if errors.Is(err, service.ErrInvalidContract) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if resolution.CoingeckoID == nil {
+		writeError(w, http.StatusNotFound, "contract not found: "+platformID+"/"+address)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resolution)
+}
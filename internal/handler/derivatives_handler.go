@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"cgoffline/internal/repository"
+)
+
+// derivativeSortFields lists the columns derivative contracts can be sorted by
+var derivativeSortFields = sortFields{
+	"open_interest_usd": true,
+	"volume_24h_usd":    true,
+	"funding_rate":      true,
+	"basis":             true,
+	"symbol":            false,
+}
+
+// derivativesExchangeSortFields lists the columns derivatives exchanges can be sorted by
+var derivativesExchangeSortFields = sortFields{
+	"open_interest_btc":    true,
+	"trade_volume_24h_btc": true,
+	"name":                 false,
+}
+
+// DerivativesHandler serves derivatives exchanges, contracts and contract history from the local database
+type DerivativesHandler struct {
+	exchangeRepo repository.DerivativesExchangeRepository
+	contractRepo repository.DerivativeContractRepository
+	snapshotRepo repository.DerivativeSnapshotRepository
+}
+
+// NewDerivativesHandler creates a new derivatives handler
+func NewDerivativesHandler(
+	exchangeRepo repository.DerivativesExchangeRepository,
+	contractRepo repository.DerivativeContractRepository,
+	snapshotRepo repository.DerivativeSnapshotRepository,
+) *DerivativesHandler {
+	return &DerivativesHandler{
+		exchangeRepo: exchangeRepo,
+		contractRepo: contractRepo,
+		snapshotRepo: snapshotRepo,
+	}
+}
+
+// ListDerivatives handles GET /derivatives
+func (h *DerivativesHandler) ListDerivatives(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, derivativeSortFields, "open_interest_usd")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	contracts, total, err := h.contractRepo.List(opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: contracts, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetDerivativeHistory handles GET /derivatives/history?market=&symbol=; days selects how far back to go (default 30)
+func (h *DerivativesHandler) GetDerivativeHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	market, symbol := query.Get("market"), query.Get("symbol")
+	if market == "" || symbol == "" {
+		writeError(w, http.StatusBadRequest, "market and symbol are required")
+		return
+	}
+
+	days := 30
+	if v := query.Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid days: "+v)
+			return
+		}
+		days = n
+	}
+
+	contract, err := h.contractRepo.GetByMarketSymbol(market, symbol)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if contract == nil {
+		writeError(w, http.StatusNotFound, "derivative contract not found: "+market+"/"+symbol)
+		return
+	}
+
+	to := time.Now().UTC()
+	snapshots, err := h.snapshotRepo.GetRange(contract.ID, to.AddDate(0, 0, -days), to)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshots)
+}
+
+// ListDerivativesExchanges handles GET /derivatives/exchanges
+func (h *DerivativesHandler) ListDerivativesExchanges(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, derivativesExchangeSortFields, "open_interest_btc")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	exchanges, total, err := h.exchangeRepo.List(opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: exchanges, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetDerivativesExchange handles GET /derivatives/exchanges/{id}
+func (h *DerivativesHandler) GetDerivativesExchange(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	exchange, err := h.exchangeRepo.GetByCoingeckoID(id)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if exchange == nil {
+		writeError(w, http.StatusNotFound, "derivatives exchange not found: "+id)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, exchange)
+}
+
+// ListDerivativesExchangeContracts handles GET /derivatives/exchanges/{id}/contracts
+func (h *DerivativesHandler) ListDerivativesExchangeContracts(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, derivativeSortFields, "open_interest_usd")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := r.PathValue("id")
+	exchange, err := h.exchangeRepo.GetByCoingeckoID(id)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if exchange == nil {
+		writeError(w, http.StatusNotFound, "derivatives exchange not found: "+id)
+		return
+	}
+
+	contracts, total, err := h.contractRepo.ListByExchange(exchange.ID, opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: contracts, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
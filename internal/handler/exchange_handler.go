@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+)
+
+// exchangeSortFields lists the columns exchanges can be sorted by
+var exchangeSortFields = sortFields{
+	"trust_score_rank":     false,
+	"trade_volume_24h_btc": true,
+}
+
+// tickerSortFields lists the columns normalized tickers can be sorted by
+var tickerSortFields = sortFields{
+	"converted_volume_usd":      true,
+	"bid_ask_spread_percentage": false,
+}
+
+// ExchangeHandler serves exchange data from the local database
+type ExchangeHandler struct {
+	repo           repository.ExchangeRepository
+	detailRepo     repository.ExchangeDetailRepository
+	marketDataRepo repository.CoinMarketDataRepository
+	volumeRepo     repository.ExchangeVolumeRepository
+}
+
+// NewExchangeHandler creates a new exchange handler
+func NewExchangeHandler(
+	repo repository.ExchangeRepository,
+	detailRepo repository.ExchangeDetailRepository,
+	marketDataRepo repository.CoinMarketDataRepository,
+	volumeRepo repository.ExchangeVolumeRepository,
+) *ExchangeHandler {
+	return &ExchangeHandler{
+		repo:           repo,
+		detailRepo:     detailRepo,
+		marketDataRepo: marketDataRepo,
+		volumeRepo:     volumeRepo,
+	}
+}
+
+// exchangeDetailResponse is an exchange together with its raw CoinGecko detail payload
+type exchangeDetailResponse struct {
+	domain.Exchange
+	Detail json.RawMessage `json:"detail,omitempty"`
+}
+
+// ListExchanges handles GET /exchanges
+func (h *ExchangeHandler) ListExchanges(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, exchangeSortFields, "trust_score_rank")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	exchanges, total, err := h.repo.List(opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: exchanges, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetExchange handles GET /exchanges/{id}
+func (h *ExchangeHandler) GetExchange(w http.ResponseWriter, r *http.Request) {
+	exchange, ok := h.lookupExchange(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.detailRepo.GetByExchangeID(exchange.ID)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	response := exchangeDetailResponse{Exchange: *exchange}
+	if detail != nil {
+		response.Detail = detail.RawJSON
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetExchangeTickers handles GET /exchanges/{id}/tickers
+func (h *ExchangeHandler) GetExchangeTickers(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, tickerSortFields, "converted_volume_usd")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	exchange, ok := h.lookupExchange(w, r)
+	if !ok {
+		return
+	}
+
+	tickers, total, err := h.marketDataRepo.ListByExchangeID(exchange.ID, opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: tickers, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetExchangeVolumeChart handles GET /exchanges/{id}/volume_chart; days selects how far back to go (default 30)
+func (h *ExchangeHandler) GetExchangeVolumeChart(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid days: "+v)
+			return
+		}
+		days = n
+	}
+
+	exchange, ok := h.lookupExchange(w, r)
+	if !ok {
+		return
+	}
+
+	to := time.Now().UTC()
+	points, err := h.volumeRepo.GetRange(exchange.ID, to.AddDate(0, 0, -days), to)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, points)
+}
+
+// lookupExchange resolves the {id} path value to a stored exchange, writing a 404 if it is unknown
+func (h *ExchangeHandler) lookupExchange(w http.ResponseWriter, r *http.Request) (*domain.Exchange, bool) {
+	id := r.PathValue("id")
+
+	exchange, err := h.repo.GetByCoingeckoID(id)
+	if err != nil {
+		writeInternalError(w, err)
+		return nil, false
+	}
+	if exchange == nil {
+		writeError(w, http.StatusNotFound, "exchange not found: "+id)
+		return nil, false
+	}
+	return exchange, true
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/internal/service"
+)
+
+// ExchangeRateHandler serves captured BTC exchange rates and converts USD amounts with them
+type ExchangeRateHandler struct {
+	repo    repository.ExchangeRateRepository
+	service service.ExchangeRateService
+}
+
+// NewExchangeRateHandler creates a new exchange rate handler
+func NewExchangeRateHandler(repo repository.ExchangeRateRepository, exchangeRateService service.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{repo: repo, service: exchangeRateService}
+}
+
+// ExchangeRatesResponse is an exchange rate snapshot keyed by currency the way /exchange_rates lists it
+type ExchangeRatesResponse struct {
+	CapturedAt time.Time                      `json:"captured_at"`
+	Rates      map[string]domain.ExchangeRate `json:"rates"`
+}
+
+// GetExchangeRates handles GET /exchange-rates; at selects the snapshot in effect at that time (default now)
+func (h *ExchangeRateHandler) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	at, ok := parseAt(w, r)
+	if !ok {
+		return
+	}
+
+	rates, err := h.repo.GetAt(at)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if len(rates) == 0 {
+		writeError(w, http.StatusNotFound, "no exchange rates captured by "+at.Format(time.RFC3339))
+		return
+	}
+
+	response := ExchangeRatesResponse{CapturedAt: rates[0].CapturedAt, Rates: make(map[string]domain.ExchangeRate, len(rates))}
+	for _, rate := range rates {
+		response.Rates[rate.Currency] = rate
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetExchangeRateHistory handles GET /exchange-rates/{currency}/history; days selects how far back to go (default 30)
+func (h *ExchangeRateHandler) GetExchangeRateHistory(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid days: "+v)
+			return
+		}
+		days = n
+	}
+
+	to := time.Now().UTC()
+	rates, err := h.repo.GetRange(strings.ToLower(r.PathValue("currency")), to.AddDate(0, 0, -days), to)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rates)
+}
+
+// ConvertUSD handles GET /exchange-rates/convert?amount=&to=; at selects the rates in effect at that time (default now)
+func (h *ExchangeRateHandler) ConvertUSD(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid amount: "+query.Get("amount"))
+		return
+	}
+	to := query.Get("to")
+	if to == "" {
+		writeError(w, http.StatusBadRequest, "to is required")
+		return
+	}
+	at, ok := parseAt(w, r)
+	if !ok {
+		return
+	}
+
+	conversion, err := h.service.ConvertUSD(amount, to, at)
+	if errors.Is(err, service.ErrNoExchangeRate) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, conversion)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"cgoffline/internal/repository"
+)
+
+// GlobalHandler serves market-wide snapshots from the local database
+type GlobalHandler struct {
+	repo repository.GlobalSnapshotRepository
+}
+
+// NewGlobalHandler creates a new global market data handler
+func NewGlobalHandler(repo repository.GlobalSnapshotRepository) *GlobalHandler {
+	return &GlobalHandler{repo: repo}
+}
+
+// GetLatest handles GET /global
+func (h *GlobalHandler) GetLatest(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.repo.GetLatest()
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if snapshot == nil {
+		writeError(w, http.StatusNotFound, "no global snapshot captured yet")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+// GetHistory handles GET /global/history; days selects how far back to go (default 30)
+func (h *GlobalHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid days: "+v)
+			return
+		}
+		days = n
+	}
+
+	to := time.Now().UTC()
+	snapshots, err := h.repo.GetRange(to.AddDate(0, 0, -days), to)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshots)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/internal/service"
+)
+
+// nftSortFields lists the columns NFT collections can be sorted by
+var nftSortFields = sortFields{
+	"market_cap_usd": true,
+	"volume_24h_usd": true,
+	"name":           false,
+}
+
+// NFTHandler serves NFT collection data from the local database
+type NFTHandler struct {
+	repo         repository.NFTCollectionRepository
+	snapshotRepo repository.NFTSnapshotRepository
+	service      service.NFTService
+}
+
+// NewNFTHandler creates a new NFT collection handler
+func NewNFTHandler(repo repository.NFTCollectionRepository, snapshotRepo repository.NFTSnapshotRepository, nftService service.NFTService) *NFTHandler {
+	return &NFTHandler{repo: repo, snapshotRepo: snapshotRepo, service: nftService}
+}
+
+// nftDetailResponse is an NFT collection together with its raw CoinGecko detail payload
+type nftDetailResponse struct {
+	domain.NFTCollection
+	Detail json.RawMessage `json:"detail,omitempty"`
+}
+
+// ListNFTs handles GET /nfts
+func (h *NFTHandler) ListNFTs(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r, nftSortFields, "market_cap_usd")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collections, total, err := h.repo.List(opts)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListResponse{Data: collections, Page: opts.Page, PerPage: opts.PerPage, Total: total})
+}
+
+// GetNFT handles GET /nfts/{id}
+func (h *NFTHandler) GetNFT(w http.ResponseWriter, r *http.Request) {
+	collection, ok := h.lookupNFT(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, nftDetailResponse{NFTCollection: *collection, Detail: collection.RawJSON})
+}
+
+// GetNFTHistory handles GET /nfts/{id}/history; days selects how far back to go (default 30)
+func (h *NFTHandler) GetNFTHistory(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid days: "+v)
+			return
+		}
+		days = n
+	}
+
+	collection, ok := h.lookupNFT(w, r)
+	if !ok {
+		return
+	}
+
+	to := time.Now().UTC()
+	snapshots, err := h.snapshotRepo.GetRange(collection.ID, to.AddDate(0, 0, -days), to)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshots)
+}
+
+// GetNFTByContract handles GET /nfts/{platform_id}/contract/{address}
+func (h *NFTHandler) GetNFTByContract(w http.ResponseWriter, r *http.Request) {
+	platformID, address := r.PathValue("platform_id"), r.PathValue("address")
+
+	collection, err := h.service.LookupContract(r.Context(), platformID, address)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if collection == nil {
+		writeError(w, http.StatusNotFound, "NFT contract not found: "+platformID+"/"+address)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, collection)
+}
+
+// lookupNFT resolves the {id} path value to a stored NFT collection, writing a 404 if it is unknown
+func (h *NFTHandler) lookupNFT(w http.ResponseWriter, r *http.Request) (*domain.NFTCollection, bool) {
+	id := r.PathValue("id")
+
+	collection, err := h.repo.GetByCoingeckoID(id)
+	if err != nil {
+		writeInternalError(w, err)
+		return nil, false
+	}
+	if collection == nil {
+		writeError(w, http.StatusNotFound, "NFT collection not found: "+id)
+		return nil, false
+	}
+	return collection, true
+}
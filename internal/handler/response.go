@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+)
+
+// ErrorResponse is the JSON body returned for failed requests
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ListResponse wraps a page of results with pagination metadata
+type ListResponse struct {
+	Data    any   `json:"data"`
+	Page    int   `json:"page"`
+	PerPage int   `json:"per_page"`
+	Total   int64 `json:"total"`
+}
+
+// sortFields maps the sortable fields of a resource to whether they default to descending order
+type sortFields map[string]bool
+
+// writeJSON encodes the payload as JSON with the given status code
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to encode JSON response")
+	}
+}
+
+// writeError writes a JSON error body with the given status code
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ErrorResponse{Error: message})
+}
+
+// writeInternalError logs the underlying error and hides it from the client
+func writeInternalError(w http.ResponseWriter, err error) {
+	logger.GetLogger().WithError(err).Error("Failed to handle request")
+	writeError(w, http.StatusInternalServerError, "internal server error")
+}
+
+// parseListOptions reads page, per_page, sort and order query parameters
+func parseListOptions(r *http.Request, fields sortFields, defaultSort string) (domain.ListOptions, error) {
+	query := r.URL.Query()
+	opts := domain.ListOptions{SortBy: defaultSort, SortDesc: fields[defaultSort]}
+
+	if v := query.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return opts, fmt.Errorf("invalid page: %s", v)
+		}
+		opts.Page = page
+	}
+
+	if v := query.Get("per_page"); v != "" {
+		perPage, err := strconv.Atoi(v)
+		if err != nil || perPage < 1 || perPage > domain.MaxPerPage {
+			return opts, fmt.Errorf("invalid per_page: %s (must be between 1 and %d)", v, domain.MaxPerPage)
+		}
+		opts.PerPage = perPage
+	}
+
+	if v := query.Get("sort"); v != "" {
+		desc, ok := fields[v]
+		if !ok {
+			return opts, fmt.Errorf("invalid sort field: %s", v)
+		}
+		opts.SortBy = v
+		opts.SortDesc = desc
+	}
+
+	switch v := query.Get("order"); v {
+	case "":
+	case "asc":
+		opts.SortDesc = false
+	case "desc":
+		opts.SortDesc = true
+	default:
+		return opts, fmt.Errorf("invalid order: %s (must be asc or desc)", v)
+	}
+
+	return opts.Normalize(), nil
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"cgoffline/pkg/config"
+	"cgoffline/pkg/logger"
+)
+
+// Handlers groups the HTTP handlers exposed by the server
+type Handlers struct {
+	Coin          *CoinHandler
+	Exchange      *ExchangeHandler
+	CoinCategory  *CoinCategoryHandler
+	AssetPlatform *AssetPlatformHandler
+	Contract      *ContractHandler
+	Global        *GlobalHandler
+	Trending      *TrendingHandler
+	NFT           *NFTHandler
+	Derivatives   *DerivativesHandler
+	ExchangeRate  *ExchangeRateHandler
+
+	// CoinGecko is optional; when set, CoinGecko v3 compatible routes are mounted under /api/v3
+	CoinGecko *CoinGeckoHandler
+}
+
+// NewRouter registers all read-only routes on a new ServeMux
+func NewRouter(h Handlers) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /coins", h.Coin.ListCoins)
+	mux.HandleFunc("GET /coins/{id}", h.Coin.GetCoin)
+	mux.HandleFunc("GET /coins/{id}/tickers", h.Coin.GetCoinTickers)
+	mux.HandleFunc("GET /coins/{id}/categories", h.Coin.GetCoinCategories)
+	mux.HandleFunc("GET /coins/{id}/contracts", h.Coin.GetCoinContracts)
+	mux.HandleFunc("GET /exchanges", h.Exchange.ListExchanges)
+	mux.HandleFunc("GET /exchanges/{id}", h.Exchange.GetExchange)
+	mux.HandleFunc("GET /exchanges/{id}/tickers", h.Exchange.GetExchangeTickers)
+	mux.HandleFunc("GET /exchanges/{id}/volume_chart", h.Exchange.GetExchangeVolumeChart)
+	mux.HandleFunc("GET /categories", h.CoinCategory.ListCoinCategories)
+	mux.HandleFunc("GET /categories/{id}/coins", h.CoinCategory.ListCategoryCoins)
+	mux.HandleFunc("GET /categories/{id}/history", h.CoinCategory.GetCategoryHistory)
+	mux.HandleFunc("GET /asset-platforms", h.AssetPlatform.ListAssetPlatforms)
+	mux.HandleFunc("GET /asset-platforms/{id}/contracts/{address}", h.AssetPlatform.GetContractCoin)
+	mux.HandleFunc("GET /asset-platforms/{id}/nfts", h.AssetPlatform.ListPlatformNFTs)
+	mux.HandleFunc("GET /contracts/{platform_id}/{address}", h.Contract.ResolveContract)
+	mux.HandleFunc("GET /nfts", h.NFT.ListNFTs)
+	mux.HandleFunc("GET /nfts/{id}", h.NFT.GetNFT)
+	mux.HandleFunc("GET /nfts/{id}/history", h.NFT.GetNFTHistory)
+	mux.HandleFunc("GET /nfts/{platform_id}/contract/{address}", h.NFT.GetNFTByContract)
+	mux.HandleFunc("GET /global", h.Global.GetLatest)
+	mux.HandleFunc("GET /global/history", h.Global.GetHistory)
+	mux.HandleFunc("GET /trending", h.Trending.GetTrending)
+	mux.HandleFunc("GET /trending/summary", h.Trending.GetTrendingSummary)
+	mux.HandleFunc("GET /top-movers", h.Trending.GetTopMovers)
+	mux.HandleFunc("GET /derivatives", h.Derivatives.ListDerivatives)
+	mux.HandleFunc("GET /derivatives/history", h.Derivatives.GetDerivativeHistory)
+	mux.HandleFunc("GET /derivatives/exchanges", h.Derivatives.ListDerivativesExchanges)
+	mux.HandleFunc("GET /derivatives/exchanges/{id}", h.Derivatives.GetDerivativesExchange)
+	mux.HandleFunc("GET /derivatives/exchanges/{id}/contracts", h.Derivatives.ListDerivativesExchangeContracts)
+	mux.HandleFunc("GET /exchange-rates", h.ExchangeRate.GetExchangeRates)
+	mux.HandleFunc("GET /exchange-rates/convert", h.ExchangeRate.ConvertUSD)
+	mux.HandleFunc("GET /exchange-rates/{currency}/history", h.ExchangeRate.GetExchangeRateHistory)
+
+	if h.CoinGecko != nil {
+		mux.HandleFunc("GET /api/v3/ping", h.CoinGecko.Ping)
+		mux.HandleFunc("GET /api/v3/coins/markets", h.CoinGecko.CoinsMarkets)
+		mux.HandleFunc("GET /api/v3/coins/list", h.CoinGecko.CoinsList)
+		mux.HandleFunc("GET /api/v3/coins/{id}", h.CoinGecko.Coin)
+		mux.HandleFunc("GET /api/v3/coins/{id}/tickers", h.CoinGecko.CoinTickers)
+		mux.HandleFunc("GET /api/v3/exchanges", h.CoinGecko.Exchanges)
+		mux.HandleFunc("GET /api/v3/asset_platforms", h.CoinGecko.AssetPlatforms)
+		mux.HandleFunc("GET /api/v3/coins/categories/list", h.CoinGecko.CoinCategoriesList)
+	}
+
+	// Anything else gets a JSON 404 instead of the default plain-text body
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not found: "+r.URL.Path)
+	})
+
+	return logRequests(mux)
+}
+
+// NewServer creates an HTTP server for the given configuration and handler
+func NewServer(cfg config.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// logRequests logs every request with its status and duration
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"status":      recorder.status,
+			"duration_ms": time.Since(start).Milliseconds(),
+		}).Info("Handled HTTP request")
+	})
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+)
+
+// TrendingHandler serves captured trending lists and top movers from the local database
+type TrendingHandler struct {
+	trendingRepo repository.TrendingRepository
+	topMoverRepo repository.TopMoverRepository
+}
+
+// NewTrendingHandler creates a new trending handler
+func NewTrendingHandler(trendingRepo repository.TrendingRepository, topMoverRepo repository.TopMoverRepository) *TrendingHandler {
+	return &TrendingHandler{trendingRepo: trendingRepo, topMoverRepo: topMoverRepo}
+}
+
+// TrendingResponse is a trending snapshot grouped the way /search/trending lists it
+type TrendingResponse struct {
+	CapturedAt time.Time             `json:"captured_at"`
+	Coins      []domain.TrendingItem `json:"coins"`
+	NFTs       []domain.TrendingItem `json:"nfts"`
+	Categories []domain.TrendingItem `json:"categories"`
+}
+
+// TopMoversResponse is a top gainers and losers snapshot grouped the way /coins/top_gainers_losers lists it
+type TopMoversResponse struct {
+	CapturedAt time.Time         `json:"captured_at"`
+	TopGainers []domain.TopMover `json:"top_gainers"`
+	TopLosers  []domain.TopMover `json:"top_losers"`
+}
+
+// GetTrending handles GET /trending; at selects the snapshot in effect at that time (default now)
+func (h *TrendingHandler) GetTrending(w http.ResponseWriter, r *http.Request) {
+	at, ok := parseAt(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := h.trendingRepo.GetAt(at)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if len(items) == 0 {
+		writeError(w, http.StatusNotFound, "no trending snapshot captured by "+at.Format(time.RFC3339))
+		return
+	}
+
+	response := TrendingResponse{
+		CapturedAt: items[0].CapturedAt,
+		Coins:      []domain.TrendingItem{},
+		NFTs:       []domain.TrendingItem{},
+		Categories: []domain.TrendingItem{},
+	}
+	for _, item := range items {
+		switch item.Kind {
+		case domain.TrendingKindCoin:
+			response.Coins = append(response.Coins, item)
+		case domain.TrendingKindNFT:
+			response.NFTs = append(response.NFTs, item)
+		case domain.TrendingKindCategory:
+			response.Categories = append(response.Categories, item)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetTrendingSummary handles GET /trending/summary. It lists what trended between from and to (YYYY-MM-DD, to is
+// exclusive and defaults to the day after from; from defaults to today), most often listed first.
+// kind selects the list: coin (default), nft or category.
+func (h *TrendingHandler) GetTrendingSummary(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	kind := query.Get("kind")
+	switch kind {
+	case "":
+		kind = domain.TrendingKindCoin
+	case domain.TrendingKindCoin, domain.TrendingKindNFT, domain.TrendingKindCategory:
+	default:
+		writeError(w, http.StatusBadRequest, "invalid kind: "+kind)
+		return
+	}
+
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if v := query.Get("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from: "+v)
+			return
+		}
+		from = t
+	}
+	to := from.AddDate(0, 0, 1)
+	if v := query.Get("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil || !t.After(from) {
+			writeError(w, http.StatusBadRequest, "invalid to: "+v)
+			return
+		}
+		to = t
+	}
+
+	summaries, err := h.trendingRepo.Summarize(kind, from, to)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+// GetTopMovers handles GET /top-movers; at selects the snapshot in effect at that time (default now)
+func (h *TrendingHandler) GetTopMovers(w http.ResponseWriter, r *http.Request) {
+	at, ok := parseAt(w, r)
+	if !ok {
+		return
+	}
+
+	movers, err := h.topMoverRepo.GetAt(at)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	if len(movers) == 0 {
+		writeError(w, http.StatusNotFound, "no top movers snapshot captured by "+at.Format(time.RFC3339))
+		return
+	}
+
+	response := TopMoversResponse{
+		CapturedAt: movers[0].CapturedAt,
+		TopGainers: []domain.TopMover{},
+		TopLosers:  []domain.TopMover{},
+	}
+	for _, mover := range movers {
+		if mover.Direction == domain.TopMoverGainer {
+			response.TopGainers = append(response.TopGainers, mover)
+		} else {
+			response.TopLosers = append(response.TopLosers, mover)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// parseAt reads the at query parameter: an RFC 3339 time, or a YYYY-MM-DD date meaning the end of that UTC day.
+// It writes a 400 response and returns false when the value is invalid.
+func parseAt(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	v := r.URL.Query().Get("at")
+	if v == "" {
+		return time.Now().UTC(), true
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), true
+	}
+	writeError(w, http.StatusBadRequest, "invalid at: "+v)
+	return time.Time{}, false
+}
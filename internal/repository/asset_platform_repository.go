@@ -68,6 +68,22 @@ func (r *assetPlatformRepository) GetAll() ([]domain.AssetPlatform, error) {
 	return platforms, nil
 }
 
+// List retrieves a page of asset platforms along with the total number of platforms
+func (r *assetPlatformRepository) List(opts domain.ListOptions) ([]domain.AssetPlatform, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.AssetPlatform{}).Count(&total).Error; err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to count asset platforms")
+		return nil, 0, fmt.Errorf("failed to count asset platforms: %w", err)
+	}
+
+	var platforms []domain.AssetPlatform
+	if err := paginate(r.db, opts, "id").Find(&platforms).Error; err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to list asset platforms")
+		return nil, 0, fmt.Errorf("failed to list asset platforms: %w", err)
+	}
+	return platforms, total, nil
+}
+
 // Update updates an existing asset platform
 func (r *assetPlatformRepository) Update(platform *domain.AssetPlatform) error {
 	if err := r.db.Save(platform).Error; err != nil {
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CategorySnapshotRepository defines the interface for coin category market snapshots
+type CategorySnapshotRepository interface {
+	CreateBatch(snapshots []domain.CategorySnapshot) error
+	GetRange(categoryID uint, from, to time.Time) ([]domain.CategorySnapshot, error)
+}
+
+type categorySnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewCategorySnapshotRepository creates a new instance of CategorySnapshotRepository
+func NewCategorySnapshotRepository(db *gorm.DB) CategorySnapshotRepository {
+	return &categorySnapshotRepository{db: db}
+}
+
+// CreateBatch appends snapshots, leaving those already stored for the same category and time untouched
+func (r *categorySnapshotRepository) CreateBatch(snapshots []domain.CategorySnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "category_id"}, {Name: "captured_at"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(snapshots, 500).Error; err != nil {
+		return fmt.Errorf("failed to create category snapshots: %w", err)
+	}
+	return nil
+}
+
+// GetRange retrieves the snapshots of a category captured within [from, to), oldest first
+func (r *categorySnapshotRepository) GetRange(categoryID uint, from, to time.Time) ([]domain.CategorySnapshot, error) {
+	var snapshots []domain.CategorySnapshot
+	if err := r.db.
+		Where("category_id = ? AND captured_at >= ? AND captured_at < ?", categoryID, from, to).
+		Order("captured_at ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category snapshot range: %w", err)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CoinCategoryMembershipRepository defines the interface for coin to category links
+type CoinCategoryMembershipRepository interface {
+	ReplaceForCoin(coinID uint, categoryNames []string) (int, error)
+	ListCoinsByCategory(categoryCoingeckoID string, opts domain.ListOptions) ([]domain.Coin, int64, error)
+	GetCategoriesByCoin(coinID uint) ([]domain.CoinCategory, error)
+}
+
+type coinCategoryMembershipRepository struct {
+	db *gorm.DB
+}
+
+// NewCoinCategoryMembershipRepository creates a new instance of CoinCategoryMembershipRepository
+func NewCoinCategoryMembershipRepository(db *gorm.DB) CoinCategoryMembershipRepository {
+	return &coinCategoryMembershipRepository{db: db}
+}
+
+// ReplaceForCoin sets the categories of a coin from the display names listed in its details.
+// Names are matched case-insensitively against listed categories; unknown names are ignored.
+// Returns how many memberships were stored.
+func (r *coinCategoryMembershipRepository) ReplaceForCoin(coinID uint, categoryNames []string) (int, error) {
+	names := make([]string, 0, len(categoryNames))
+	for _, name := range categoryNames {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+
+	var stored int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("coin_id = ?", coinID).Delete(&domain.CoinCategoryMembership{}).Error; err != nil {
+			return fmt.Errorf("failed to delete coin category memberships: %w", err)
+		}
+		if len(names) == 0 {
+			return nil
+		}
+
+		result := tx.Exec(`
+			INSERT INTO coin_category_memberships (coin_id, category_id, created_at)
+			SELECT ?, id, ?
+			FROM coin_categories
+			WHERE deleted_at IS NULL AND lower(name) IN ?
+			ON CONFLICT DO NOTHING
+		`, coinID, time.Now(), names)
+		if result.Error != nil {
+			return fmt.Errorf("failed to insert coin category memberships: %w", result.Error)
+		}
+		stored = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(stored), nil
+}
+
+// ListCoinsByCategory retrieves a page of the coins in a category along with their total number
+func (r *coinCategoryMembershipRepository) ListCoinsByCategory(categoryCoingeckoID string, opts domain.ListOptions) ([]domain.Coin, int64, error) {
+	members := r.db.
+		Table("coin_category_memberships m").
+		Select("m.coin_id").
+		Joins("JOIN coin_categories c ON c.id = m.category_id AND c.deleted_at IS NULL").
+		Where("c.coingecko_id = ?", categoryCoingeckoID)
+
+	var total int64
+	if err := r.db.Model(&domain.Coin{}).Where("id IN (?)", members).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count coins by category: %w", err)
+	}
+
+	var coins []domain.Coin
+	if err := paginate(r.db.Where("id IN (?)", members), opts, "market_cap_rank").Find(&coins).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list coins by category: %w", err)
+	}
+	return coins, total, nil
+}
+
+// GetCategoriesByCoin retrieves the listed categories a coin belongs to, ordered by name
+func (r *coinCategoryMembershipRepository) GetCategoriesByCoin(coinID uint) ([]domain.CoinCategory, error) {
+	var categories []domain.CoinCategory
+	if err := r.db.
+		Joins("JOIN coin_category_memberships m ON m.category_id = coin_categories.id").
+		Where("m.coin_id = ?", coinID).
+		Order("coin_categories.name").
+		Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get categories by coin: %w", err)
+	}
+	return categories, nil
+}
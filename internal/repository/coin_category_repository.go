@@ -7,6 +7,7 @@ import (
 	"cgoffline/pkg/logger"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // coinCategoryRepository implements the CoinCategoryRepository interface
@@ -58,12 +59,12 @@ func (r *coinCategoryRepository) GetByID(id uint) (*domain.CoinCategory, error)
 	return &category, nil
 }
 
-// GetByCoingeckoID retrieves a coin category by CoinGecko ID
+// GetByCoingeckoID retrieves a coin category by CoinGecko ID, or nil when it is not stored
 func (r *coinCategoryRepository) GetByCoingeckoID(coingeckoID string) (*domain.CoinCategory, error) {
 	var category domain.CoinCategory
 	if err := r.db.First(&category, "coingecko_id = ?", coingeckoID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("coin category with CoinGecko ID %s not found", coingeckoID)
+			return nil, nil
 		}
 		logger.GetLogger().WithError(err).WithField("coingecko_id", coingeckoID).Error("Failed to get coin category by CoinGecko ID")
 		return nil, fmt.Errorf("failed to get coin category by CoinGecko ID: %w", err)
@@ -81,6 +82,22 @@ func (r *coinCategoryRepository) GetAll() ([]domain.CoinCategory, error) {
 	return categories, nil
 }
 
+// List retrieves a page of coin categories along with the total number of categories
+func (r *coinCategoryRepository) List(opts domain.ListOptions) ([]domain.CoinCategory, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.CoinCategory{}).Count(&total).Error; err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to count coin categories")
+		return nil, 0, fmt.Errorf("failed to count coin categories: %w", err)
+	}
+
+	var categories []domain.CoinCategory
+	if err := paginate(r.db, opts, "name").Find(&categories).Error; err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to list coin categories")
+		return nil, 0, fmt.Errorf("failed to list coin categories: %w", err)
+	}
+	return categories, total, nil
+}
+
 // Update updates an existing coin category
 func (r *coinCategoryRepository) Update(category *domain.CoinCategory) error {
 	if err := r.db.Save(category).Error; err != nil {
@@ -150,3 +167,66 @@ func (r *coinCategoryRepository) UpsertBatch(categories []domain.CoinCategory) e
 		return nil
 	})
 }
+
+// GetIDsByCoingeckoIDs maps CoinGecko category IDs to local category IDs, including soft-deleted categories.
+// IDs that are not stored are left out of the map.
+func (r *coinCategoryRepository) GetIDsByCoingeckoIDs(coingeckoIDs []string) (map[string]uint, error) {
+	ids := make(map[string]uint, len(coingeckoIDs))
+	if len(coingeckoIDs) == 0 {
+		return ids, nil
+	}
+
+	var rows []struct {
+		ID          uint
+		CoingeckoID string
+	}
+	if err := r.db.Unscoped().Model(&domain.CoinCategory{}).
+		Select("id, coingecko_id").
+		Where("coingecko_id IN ?", coingeckoIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin category ids by coingecko_ids: %w", err)
+	}
+
+	for _, row := range rows {
+		ids[row.CoingeckoID] = row.ID
+	}
+	return ids, nil
+}
+
+// UpsertMarketData creates or updates coin categories together with their /coins/categories market figures.
+// Soft-deleted categories are updated but stay deleted; only the categories list sync decides what is listed.
+func (r *coinCategoryRepository) UpsertMarketData(categories []domain.CoinCategory) error {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Select("coingecko_id", "name", "content", "market_cap", "market_cap_change_24h", "volume_24h",
+			"top_3_coin_ids", "top_3_coins", "market_data_updated_at", "created_at", "updated_at").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "coingecko_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"name", "content", "market_cap", "market_cap_change_24h", "volume_24h",
+				"top_3_coin_ids", "top_3_coins", "market_data_updated_at", "updated_at",
+			}),
+		}).
+		CreateInBatches(categories, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(categories)).Error("Failed to upsert coin categories market data")
+		return fmt.Errorf("failed to upsert coin categories market data: %w", err)
+	}
+	return nil
+}
+
+// MarkDelisted soft-deletes the coin categories whose coingecko_id is not in listed and returns how many were marked.
+// A removed category comes back to life when an upsert sees it again.
+func (r *coinCategoryRepository) MarkDelisted(listed []string) (int64, error) {
+	if len(listed) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("coingecko_id NOT IN ?", listed).Delete(&domain.CoinCategory{})
+	if result.Error != nil {
+		logger.GetLogger().WithError(result.Error).Error("Failed to mark delisted coin categories")
+		return 0, fmt.Errorf("failed to mark delisted coin categories: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
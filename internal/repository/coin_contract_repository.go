@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CoinContractRepository defines the interface for coin contract addresses
+type CoinContractRepository interface {
+	ReplaceForCoin(coinID uint, contracts []domain.CoinContract) error
+	UpsertAddresses(contracts []domain.CoinContract) error
+	GetByCoinID(coinID uint) ([]domain.CoinContract, error)
+	GetCoinByAddress(assetPlatformID, address string) (*domain.Coin, error)
+}
+
+type coinContractRepository struct {
+	db *gorm.DB
+}
+
+// NewCoinContractRepository creates a new instance of CoinContractRepository
+func NewCoinContractRepository(db *gorm.DB) CoinContractRepository {
+	return &coinContractRepository{db: db}
+}
+
+// ReplaceForCoin sets the contracts of a coin, dropping the ones it no longer lists
+func (r *coinContractRepository) ReplaceForCoin(coinID uint, contracts []domain.CoinContract) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("coin_id = ?", coinID).Delete(&domain.CoinContract{}).Error; err != nil {
+			return fmt.Errorf("failed to delete coin contracts: %w", err)
+		}
+		if len(contracts) == 0 {
+			return nil
+		}
+		for i := range contracts {
+			contracts[i].CoinID = coinID
+			contracts[i].ContractAddress = domain.NormalizeContractAddress(contracts[i].ContractAddress)
+		}
+		if err := tx.Create(&contracts).Error; err != nil {
+			return fmt.Errorf("failed to create coin contracts: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpsertAddresses creates or updates contracts known only by address, such as those from the coin list.
+// Stored decimals are kept while the address stays the same; other contracts of the coins are left alone.
+func (r *coinContractRepository) UpsertAddresses(contracts []domain.CoinContract) error {
+	if len(contracts) == 0 {
+		return nil
+	}
+	for i := range contracts {
+		contracts[i].ContractAddress = domain.NormalizeContractAddress(contracts[i].ContractAddress)
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "coin_id"}, {Name: "asset_platform_id"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "decimal_place"}, Value: gorm.Expr("CASE WHEN coin_contracts.contract_address = EXCLUDED.contract_address THEN coin_contracts.decimal_place END")},
+				{Column: clause.Column{Name: "contract_address"}, Value: gorm.Expr("EXCLUDED.contract_address")},
+				{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("EXCLUDED.updated_at")},
+			},
+		}).
+		CreateInBatches(contracts, 500).Error; err != nil {
+		return fmt.Errorf("failed to upsert coin contract addresses: %w", err)
+	}
+	return nil
+}
+
+// GetByCoinID retrieves the contracts of a coin ordered by asset platform
+func (r *coinContractRepository) GetByCoinID(coinID uint) ([]domain.CoinContract, error) {
+	var contracts []domain.CoinContract
+	if err := r.db.Where("coin_id = ?", coinID).Order("asset_platform_id").Find(&contracts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin contracts by coin id: %w", err)
+	}
+	return contracts, nil
+}
+
+// GetCoinByAddress retrieves the coin deployed at a contract address on an asset platform.
+// When several coins list the same contract, the one with the best market cap rank wins.
+func (r *coinContractRepository) GetCoinByAddress(assetPlatformID, address string) (*domain.Coin, error) {
+	var coin domain.Coin
+	if err := r.db.
+		Joins("JOIN coin_contracts cc ON cc.coin_id = coins.id").
+		Where("cc.asset_platform_id = ? AND cc.contract_address = ?", assetPlatformID, domain.NormalizeContractAddress(address)).
+		Order("coins.market_cap_rank ASC NULLS LAST, coins.id").
+		First(&coin).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get coin by contract address: %w", err)
+	}
+	return &coin, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"cgoffline/internal/domain"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,6 +12,9 @@ import (
 type CoinDetailRepository interface {
 	Upsert(detail domain.CoinDetail) error
 	GetByCoinID(coinID uint) (*domain.CoinDetail, error)
+	GetPlatforms() (map[string][]byte, error)
+	GetFreshCoinIDs(since time.Time) (map[uint]bool, error)
+	GetCoingeckoIDByContract(assetPlatformID, address string) (string, error)
 }
 
 type coinDetailRepository struct {
@@ -42,3 +46,64 @@ func (r *coinDetailRepository) GetByCoinID(coinID uint) (*domain.CoinDetail, err
 	}
 	return &d, nil
 }
+
+// GetPlatforms returns the raw "platforms" object of every stored coin detail keyed by CoinGecko ID
+func (r *coinDetailRepository) GetPlatforms() (map[string][]byte, error) {
+	var rows []struct {
+		CoingeckoID string
+		Platforms   []byte
+	}
+	if err := r.db.Model(&domain.CoinDetail{}).
+		Select("coingecko_id, raw_json->'platforms' AS platforms").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin detail platforms: %w", err)
+	}
+
+	platforms := make(map[string][]byte, len(rows))
+	for _, row := range rows {
+		if len(row.Platforms) > 0 {
+			platforms[row.CoingeckoID] = row.Platforms
+		}
+	}
+	return platforms, nil
+}
+
+// GetFreshCoinIDs returns the IDs of coins whose details were stored after the given time
+func (r *coinDetailRepository) GetFreshCoinIDs(since time.Time) (map[uint]bool, error) {
+	var ids []uint
+	if err := r.db.Model(&domain.CoinDetail{}).
+		Where("updated_at > ?", since).
+		Pluck("coin_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get fresh coin detail ids: %w", err)
+	}
+
+	fresh := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		fresh[id] = true
+	}
+	return fresh, nil
+}
+
+// GetCoingeckoIDByContract searches the "platforms" object of the stored payloads for a contract address
+// and returns the CoinGecko ID of the coin listing it, or "" when none does
+func (r *coinDetailRepository) GetCoingeckoIDByContract(assetPlatformID, address string) (string, error) {
+	address = domain.NormalizeContractAddress(address)
+	column := "raw_json->'platforms'->>?"
+	if strings.HasPrefix(address, "0x") {
+		// Payloads keep checksummed hex addresses
+		column = "lower(" + column + ")"
+	}
+
+	var ids []string
+	if err := r.db.Model(&domain.CoinDetail{}).
+		Where(column+" = ?", assetPlatformID, address).
+		Order("coingecko_id").
+		Limit(1).
+		Pluck("coingecko_id", &ids).Error; err != nil {
+		return "", fmt.Errorf("failed to get coin detail by contract address: %w", err)
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+	return ids[0], nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CoinMarketChartRepository defines the interface for backfilled market chart points
+type CoinMarketChartRepository interface {
+	CreateBatch(points []domain.CoinMarketChartPoint) error
+	GetTimestamps(coinID uint, vsCurrency, granularity string, from, to time.Time) ([]time.Time, error)
+	GetRange(coinID uint, vsCurrency, granularity string, from, to time.Time) ([]domain.CoinMarketChartPoint, error)
+	HasPointsBefore(coinID uint, vsCurrency, granularity string, before time.Time) (bool, error)
+	GetAvailableFrom(coinID uint, vsCurrency, granularity string) (*time.Time, error)
+	SetAvailableFrom(coinID uint, vsCurrency, granularity string, availableFrom time.Time) error
+}
+
+type coinMarketChartRepository struct {
+	db *gorm.DB
+}
+
+// NewCoinMarketChartRepository creates a new instance of CoinMarketChartRepository
+func NewCoinMarketChartRepository(db *gorm.DB) CoinMarketChartRepository {
+	return &coinMarketChartRepository{db: db}
+}
+
+// CreateBatch inserts chart points, leaving points that are already stored untouched
+func (r *coinMarketChartRepository) CreateBatch(points []domain.CoinMarketChartPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "coin_id"}, {Name: "vs_currency"}, {Name: "granularity"}, {Name: "timestamp"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(points, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(points)).Error("Failed to create coin market chart points batch")
+		return fmt.Errorf("failed to create coin market chart points batch: %w", err)
+	}
+
+	logger.GetLogger().WithField("count", len(points)).Debug("Successfully created coin market chart points batch")
+	return nil
+}
+
+// GetTimestamps retrieves the timestamps already stored for a coin series within [from, to)
+func (r *coinMarketChartRepository) GetTimestamps(coinID uint, vsCurrency, granularity string, from, to time.Time) ([]time.Time, error) {
+	var timestamps []time.Time
+	if err := r.db.Model(&domain.CoinMarketChartPoint{}).
+		Where("coin_id = ? AND vs_currency = ? AND granularity = ? AND timestamp >= ? AND timestamp < ?", coinID, vsCurrency, granularity, from, to).
+		Order("timestamp ASC").
+		Pluck("timestamp", &timestamps).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin market chart timestamps: %w", err)
+	}
+	return timestamps, nil
+}
+
+// GetRange retrieves the chart points of a coin series within [from, to), oldest first
+func (r *coinMarketChartRepository) GetRange(coinID uint, vsCurrency, granularity string, from, to time.Time) ([]domain.CoinMarketChartPoint, error) {
+	var points []domain.CoinMarketChartPoint
+	if err := r.db.
+		Where("coin_id = ? AND vs_currency = ? AND granularity = ? AND timestamp >= ? AND timestamp < ?", coinID, vsCurrency, granularity, from, to).
+		Order("timestamp ASC").
+		Find(&points).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin market chart range: %w", err)
+	}
+	return points, nil
+}
+
+// HasPointsBefore reports whether any chart point of a coin series is stored before the given time
+func (r *coinMarketChartRepository) HasPointsBefore(coinID uint, vsCurrency, granularity string, before time.Time) (bool, error) {
+	var exists bool
+	if err := r.db.Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM coin_market_chart_points
+			WHERE coin_id = ? AND vs_currency = ? AND granularity = ? AND timestamp < ?
+		)
+	`, coinID, vsCurrency, granularity, before).Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("failed to check for earlier coin market chart points: %w", err)
+	}
+	return exists, nil
+}
+
+// GetAvailableFrom returns where CoinGecko's history of a coin series starts, or nil when that is not known yet
+func (r *coinMarketChartRepository) GetAvailableFrom(coinID uint, vsCurrency, granularity string) (*time.Time, error) {
+	var coverage domain.CoinMarketChartCoverage
+	if err := r.db.
+		Where("coin_id = ? AND vs_currency = ? AND granularity = ?", coinID, vsCurrency, granularity).
+		First(&coverage).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get coin market chart coverage: %w", err)
+	}
+	availableFrom := coverage.AvailableFrom.UTC()
+	return &availableFrom, nil
+}
+
+// SetAvailableFrom records where CoinGecko's history of a coin series starts; a recorded start is only moved forward
+func (r *coinMarketChartRepository) SetAvailableFrom(coinID uint, vsCurrency, granularity string, availableFrom time.Time) error {
+	coverage := domain.CoinMarketChartCoverage{
+		CoinID:        coinID,
+		VsCurrency:    vsCurrency,
+		Granularity:   granularity,
+		AvailableFrom: availableFrom,
+	}
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "coin_id"}, {Name: "vs_currency"}, {Name: "granularity"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "available_from"}, Value: gorm.Expr("GREATEST(coin_market_chart_coverage.available_from, EXCLUDED.available_from)")},
+				{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("EXCLUDED.updated_at")},
+			},
+		}).
+		Create(&coverage).Error; err != nil {
+		return fmt.Errorf("failed to set coin market chart coverage: %w", err)
+	}
+	return nil
+}
@@ -14,9 +14,14 @@ type CoinMarketDataRepository interface {
 	GetAll() ([]domain.CoinMarketData, error)
 	GetByCoinID(coinID uint) ([]domain.CoinMarketData, error)
 	GetByExchangeID(exchangeID uint) ([]domain.CoinMarketData, error)
+	ListByCoinID(coinID uint, opts domain.ListOptions) ([]domain.CoinMarketData, int64, error)
+	ListByExchangeID(exchangeID uint, opts domain.ListOptions) ([]domain.CoinMarketData, int64, error)
 	Upsert(marketData domain.CoinMarketData) error
 	UpsertBatch(marketData []domain.CoinMarketData) error
 	DeleteByCoinID(coinID uint) error
+	DeleteStaleByCoinID(coinID uint, source string, before time.Time) error
+	DeleteStaleByExchangeID(exchangeID uint, source string, before time.Time) error
+	GetByPair(base, target string, trustScore string) ([]domain.CoinMarketData, error)
 }
 
 type coinMarketDataRepository struct {
@@ -55,6 +60,36 @@ func (r *coinMarketDataRepository) GetByExchangeID(exchangeID uint) ([]domain.Co
 	return marketData, nil
 }
 
+// ListByCoinID retrieves a page of a coin's tickers along with their total number
+func (r *coinMarketDataRepository) ListByCoinID(coinID uint, opts domain.ListOptions) ([]domain.CoinMarketData, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.CoinMarketData{}).Where("coin_id = ?", coinID).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count market data by coin_id: %w", err)
+	}
+
+	var marketData []domain.CoinMarketData
+	if err := paginate(r.db.Preload("Exchange").Where("coin_id = ?", coinID), opts, "converted_volume_usd").
+		Find(&marketData).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list market data by coin_id: %w", err)
+	}
+	return marketData, total, nil
+}
+
+// ListByExchangeID retrieves a page of an exchange's tickers along with their total number
+func (r *coinMarketDataRepository) ListByExchangeID(exchangeID uint, opts domain.ListOptions) ([]domain.CoinMarketData, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.CoinMarketData{}).Where("exchange_id = ?", exchangeID).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count market data by exchange_id: %w", err)
+	}
+
+	var marketData []domain.CoinMarketData
+	if err := paginate(r.db.Preload("Coin").Where("exchange_id = ?", exchangeID), opts, "converted_volume_usd").
+		Find(&marketData).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list market data by exchange_id: %w", err)
+	}
+	return marketData, total, nil
+}
+
 // Upsert creates a new market data record or updates an existing one
 func (r *coinMarketDataRepository) Upsert(marketData domain.CoinMarketData) error {
 	// Set CreatedAt and UpdatedAt for new records or update UpdatedAt for existing
@@ -64,7 +99,7 @@ func (r *coinMarketDataRepository) Upsert(marketData domain.CoinMarketData) erro
 	marketData.UpdatedAt = time.Now()
 
 	if err := r.db.
-		Where("coin_id = ? AND exchange_id = ?", marketData.CoinID, marketData.ExchangeID).
+		Where("coin_id = ? AND exchange_id = ? AND base = ? AND target = ?", marketData.CoinID, marketData.ExchangeID, marketData.Base, marketData.Target).
 		Assign(marketData).
 		FirstOrCreate(&marketData).Error; err != nil {
 		return fmt.Errorf("failed to upsert coin market data: %w", err)
@@ -98,31 +133,47 @@ func (r *coinMarketDataRepository) UpsertBatch(marketData []domain.CoinMarketDat
 	}
 
 	// Use a transaction for batch upsert
+	now := time.Now()
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		for _, data := range validMarketData {
 			// Use ON CONFLICT for proper upsert
 			if err := tx.Exec(`
 				INSERT INTO coin_market_data (
-					coin_id, exchange_id, price, volume_24h, volume_percentage,
-					last_updated, created_at, updated_at, deleted_at
+					coin_id, exchange_id, base, target, source, price, volume_24h, volume_percentage,
+					converted_last_usd, converted_volume_usd, bid_ask_spread_percentage, trust_score,
+					is_anomaly, is_stale, last_updated, last_traded_at, last_fetch_at,
+					created_at, updated_at, deleted_at
 				)
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-				ON CONFLICT (coin_id, exchange_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+				ON CONFLICT (coin_id, exchange_id, base, target)
 				DO UPDATE SET
+					source = EXCLUDED.source,
 					price = EXCLUDED.price,
 					volume_24h = EXCLUDED.volume_24h,
 					volume_percentage = EXCLUDED.volume_percentage,
+					converted_last_usd = EXCLUDED.converted_last_usd,
+					converted_volume_usd = EXCLUDED.converted_volume_usd,
+					bid_ask_spread_percentage = EXCLUDED.bid_ask_spread_percentage,
+					trust_score = EXCLUDED.trust_score,
+					is_anomaly = EXCLUDED.is_anomaly,
+					is_stale = EXCLUDED.is_stale,
 					last_updated = EXCLUDED.last_updated,
+					last_traded_at = EXCLUDED.last_traded_at,
+					last_fetch_at = EXCLUDED.last_fetch_at,
 					updated_at = EXCLUDED.updated_at,
 					deleted_at = EXCLUDED.deleted_at
 			`,
-				data.CoinID, data.ExchangeID, data.Price, data.Volume24h, data.VolumePercentage,
-				data.LastUpdated, data.CreatedAt, data.UpdatedAt, data.DeletedAt).Error; err != nil {
+				data.CoinID, data.ExchangeID, data.Base, data.Target, data.Source, data.Price, data.Volume24h, data.VolumePercentage,
+				data.ConvertedLastUSD, data.ConvertedVolumeUSD, data.BidAskSpreadPercentage, data.TrustScore,
+				data.IsAnomaly, data.IsStale, data.LastUpdated, data.LastTradedAt, data.LastFetchAt,
+				now, now, nil).Error; err != nil {
 				logger.GetLogger().WithError(err).WithFields(map[string]interface{}{
 					"coin_id":     data.CoinID,
 					"exchange_id": data.ExchangeID,
+					"base":        data.Base,
+					"target":      data.Target,
 				}).Error("Failed to upsert market data in batch")
-				return fmt.Errorf("failed to upsert market data for coin %d on exchange %d: %w", data.CoinID, data.ExchangeID, err)
+				return fmt.Errorf("failed to upsert market data for coin %d on exchange %d (%s/%s): %w", data.CoinID, data.ExchangeID, data.Base, data.Target, err)
 			}
 		}
 		logger.GetLogger().WithField("count", len(validMarketData)).Info("Successfully upserted coin market data batch")
@@ -137,3 +188,49 @@ func (r *coinMarketDataRepository) DeleteByCoinID(coinID uint) error {
 	}
 	return nil
 }
+
+// DeleteStaleByCoinID soft-deletes the tickers of a coin written by source that were not updated since the given time
+func (r *coinMarketDataRepository) DeleteStaleByCoinID(coinID uint, source string, before time.Time) error {
+	result := r.db.Where("coin_id = ? AND source = ? AND updated_at < ?", coinID, source, before).Delete(&domain.CoinMarketData{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete stale market data by coin_id: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"coin_id": coinID,
+			"count":   result.RowsAffected,
+		}).Info("Removed tickers no longer listed by CoinGecko")
+	}
+	return nil
+}
+
+// DeleteStaleByExchangeID soft-deletes the tickers of an exchange written by source that were not updated since the given time
+func (r *coinMarketDataRepository) DeleteStaleByExchangeID(exchangeID uint, source string, before time.Time) error {
+	result := r.db.Where("exchange_id = ? AND source = ? AND updated_at < ?", exchangeID, source, before).Delete(&domain.CoinMarketData{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete stale market data by exchange_id: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"exchange_id": exchangeID,
+			"count":       result.RowsAffected,
+		}).Info("Removed exchange tickers no longer listed by CoinGecko")
+	}
+	return nil
+}
+
+// GetByPair retrieves the tickers of a base/target pair across exchanges, optionally limited to a trust score.
+// Symbols are matched case-insensitively; results are ordered by USD volume.
+func (r *coinMarketDataRepository) GetByPair(base, target string, trustScore string) ([]domain.CoinMarketData, error) {
+	query := r.db.Preload("Exchange").
+		Where("UPPER(base) = UPPER(?) AND UPPER(target) = UPPER(?)", base, target)
+	if trustScore != "" {
+		query = query.Where("trust_score = ?", trustScore)
+	}
+
+	var marketData []domain.CoinMarketData
+	if err := query.Order("converted_volume_usd DESC NULLS LAST").Find(&marketData).Error; err != nil {
+		return nil, fmt.Errorf("failed to get market data by pair: %w", err)
+	}
+	return marketData, nil
+}
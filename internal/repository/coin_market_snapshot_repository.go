@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CoinMarketSnapshotRepository defines the interface for historical coin market data
+type CoinMarketSnapshotRepository interface {
+	CreateBatch(snapshots []domain.CoinMarketSnapshot) error
+	GetAt(coinID uint, at time.Time) (*domain.CoinMarketSnapshot, error)
+	GetRange(coinID uint, from, to time.Time) ([]domain.CoinMarketSnapshot, error)
+}
+
+type coinMarketSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewCoinMarketSnapshotRepository creates a new instance of CoinMarketSnapshotRepository
+func NewCoinMarketSnapshotRepository(db *gorm.DB) CoinMarketSnapshotRepository {
+	return &coinMarketSnapshotRepository{db: db}
+}
+
+// CreateBatch appends snapshots, ignoring any that already exist for the same coin and capture time
+func (r *coinMarketSnapshotRepository) CreateBatch(snapshots []domain.CoinMarketSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "coin_id"}, {Name: "captured_at"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(snapshots, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(snapshots)).Error("Failed to create coin market snapshots batch")
+		return fmt.Errorf("failed to create coin market snapshots batch: %w", err)
+	}
+
+	logger.GetLogger().WithField("count", len(snapshots)).Info("Successfully created coin market snapshots batch")
+	return nil
+}
+
+// GetAt retrieves the latest snapshot of a coin captured at or before the given time
+func (r *coinMarketSnapshotRepository) GetAt(coinID uint, at time.Time) (*domain.CoinMarketSnapshot, error) {
+	var snapshot domain.CoinMarketSnapshot
+	if err := r.db.
+		Where("coin_id = ? AND captured_at <= ?", coinID, at).
+		Order("captured_at DESC").
+		First(&snapshot).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get coin market snapshot at %s: %w", at.Format(time.RFC3339), err)
+	}
+	return &snapshot, nil
+}
+
+// GetRange retrieves all snapshots of a coin captured within [from, to), oldest first
+func (r *coinMarketSnapshotRepository) GetRange(coinID uint, from, to time.Time) ([]domain.CoinMarketSnapshot, error) {
+	var snapshots []domain.CoinMarketSnapshot
+	if err := r.db.
+		Where("coin_id = ? AND captured_at >= ? AND captured_at < ?", coinID, from, to).
+		Order("captured_at ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin market snapshots range: %w", err)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CoinOHLCRepository defines the interface for OHLC candle data operations
+type CoinOHLCRepository interface {
+	UpsertBatch(candles []domain.CoinOHLC) error
+	GetLatestOpenTime(coinID uint, vsCurrency, interval string) (*time.Time, error)
+	GetRange(coinID uint, vsCurrency, interval string, from, to time.Time) ([]domain.CoinOHLC, error)
+}
+
+type coinOHLCRepository struct {
+	db *gorm.DB
+}
+
+// NewCoinOHLCRepository creates a new instance of CoinOHLCRepository
+func NewCoinOHLCRepository(db *gorm.DB) CoinOHLCRepository {
+	return &coinOHLCRepository{db: db}
+}
+
+// UpsertBatch inserts candles, overwriting prices of candles already stored so a still-forming candle is refreshed
+func (r *coinOHLCRepository) UpsertBatch(candles []domain.CoinOHLC) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "coin_id"}, {Name: "vs_currency"}, {Name: "interval"}, {Name: "open_time"}},
+			DoUpdates: clause.AssignmentColumns([]string{"open", "high", "low", "close", "updated_at"}),
+		}).
+		CreateInBatches(candles, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(candles)).Error("Failed to upsert coin OHLC batch")
+		return fmt.Errorf("failed to upsert coin OHLC batch: %w", err)
+	}
+
+	logger.GetLogger().WithField("count", len(candles)).Debug("Successfully upserted coin OHLC batch")
+	return nil
+}
+
+// GetLatestOpenTime returns the open time of the newest stored candle, or nil when none is stored
+func (r *coinOHLCRepository) GetLatestOpenTime(coinID uint, vsCurrency, interval string) (*time.Time, error) {
+	var candle domain.CoinOHLC
+	if err := r.db.
+		Where("coin_id = ? AND vs_currency = ? AND interval = ?", coinID, vsCurrency, interval).
+		Order("open_time DESC").
+		First(&candle).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest coin OHLC open time: %w", err)
+	}
+	openTime := candle.OpenTime.UTC()
+	return &openTime, nil
+}
+
+// GetRange retrieves the candles of a coin opened within [from, to), oldest first
+func (r *coinOHLCRepository) GetRange(coinID uint, vsCurrency, interval string, from, to time.Time) ([]domain.CoinOHLC, error) {
+	var candles []domain.CoinOHLC
+	if err := r.db.
+		Where("coin_id = ? AND vs_currency = ? AND interval = ? AND open_time >= ? AND open_time < ?", coinID, vsCurrency, interval, from, to).
+		Order("open_time ASC").
+		Find(&candles).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin OHLC range: %w", err)
+	}
+	return candles, nil
+}
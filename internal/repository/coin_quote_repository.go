@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CoinQuoteRepository defines the interface for per-currency coin quotes
+type CoinQuoteRepository interface {
+	UpsertBatch(quotes []domain.CoinQuote) error
+	GetByCoinIDs(coinIDs []uint, vsCurrency string) (map[uint]domain.CoinQuote, error)
+	GetCurrencies() ([]string, error)
+}
+
+type coinQuoteRepository struct {
+	db *gorm.DB
+}
+
+// NewCoinQuoteRepository creates a new instance of CoinQuoteRepository
+func NewCoinQuoteRepository(db *gorm.DB) CoinQuoteRepository {
+	return &coinQuoteRepository{db: db}
+}
+
+// UpsertBatch creates or updates quotes keyed by coin and currency
+func (r *coinQuoteRepository) UpsertBatch(quotes []domain.CoinQuote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "coin_id"}, {Name: "vs_currency"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"current_price", "market_cap", "fully_diluted_valuation", "total_volume",
+				"high_24h", "low_24h", "price_change_24h", "price_change_percentage_24h",
+				"market_cap_change_24h", "market_cap_change_percentage_24h",
+				"ath", "ath_change_percentage", "ath_date", "atl", "atl_change_percentage", "atl_date",
+				"last_updated", "updated_at",
+			}),
+		}).
+		CreateInBatches(quotes, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(quotes)).Error("Failed to upsert coin quotes batch")
+		return fmt.Errorf("failed to upsert coin quotes batch: %w", err)
+	}
+	return nil
+}
+
+// GetByCoinIDs retrieves the quotes of the given coins in one currency, keyed by coin ID
+func (r *coinQuoteRepository) GetByCoinIDs(coinIDs []uint, vsCurrency string) (map[uint]domain.CoinQuote, error) {
+	quotes := make(map[uint]domain.CoinQuote, len(coinIDs))
+	if len(coinIDs) == 0 {
+		return quotes, nil
+	}
+
+	var rows []domain.CoinQuote
+	if err := r.db.Where("coin_id IN ? AND vs_currency = ?", coinIDs, vsCurrency).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin quotes: %w", err)
+	}
+	for _, q := range rows {
+		quotes[q.CoinID] = q
+	}
+	return quotes, nil
+}
+
+// GetCurrencies lists the currencies that have stored quotes
+func (r *coinQuoteRepository) GetCurrencies() ([]string, error) {
+	var currencies []string
+	if err := r.db.Model(&domain.CoinQuote{}).Distinct().Order("vs_currency").Pluck("vs_currency", &currencies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin quote currencies: %w", err)
+	}
+	return currencies, nil
+}
@@ -3,18 +3,25 @@ package repository
 import (
 	"cgoffline/internal/domain"
 	"cgoffline/pkg/logger"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CoinRepository defines the interface for coin data operations
 type CoinRepository interface {
 	GetAll() ([]domain.Coin, error)
+	List(opts domain.ListOptions) ([]domain.Coin, int64, error)
+	ListByCoingeckoIDs(coingeckoIDs []string, opts domain.ListOptions) ([]domain.Coin, error)
 	GetByCoingeckoID(coingeckoID string) (*domain.Coin, error)
+	GetIDsByCoingeckoIDs(coingeckoIDs []string) (map[string]uint, error)
 	Upsert(coin domain.Coin) error
 	UpsertBatch(coins []domain.Coin) error
+	UpsertListed(coins []domain.Coin) error
+	MarkDelisted(listed []string) (int64, error)
 }
 
 type coinRepository struct {
@@ -35,6 +42,31 @@ func (r *coinRepository) GetAll() ([]domain.Coin, error) {
 	return coins, nil
 }
 
+// List retrieves a page of active coins along with the total number of active coins. Inactive coins keep the
+// last market data they were listed with, so they are left out rather than served with stale prices and ranks
+func (r *coinRepository) List(opts domain.ListOptions) ([]domain.Coin, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.Coin{}).Where("active = ?", true).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count coins: %w", err)
+	}
+
+	var coins []domain.Coin
+	if err := paginate(r.db.Where("active = ?", true), opts, "market_cap_rank").Find(&coins).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list coins: %w", err)
+	}
+	return coins, total, nil
+}
+
+// ListByCoingeckoIDs retrieves a page of active coins restricted to the given CoinGecko IDs
+func (r *coinRepository) ListByCoingeckoIDs(coingeckoIDs []string, opts domain.ListOptions) ([]domain.Coin, error) {
+	var coins []domain.Coin
+	query := paginate(r.db.Where("coingecko_id IN ? AND active = ?", coingeckoIDs, true), opts, "market_cap_rank")
+	if err := query.Find(&coins).Error; err != nil {
+		return nil, fmt.Errorf("failed to list coins by coingecko_ids: %w", err)
+	}
+	return coins, nil
+}
+
 // GetByCoingeckoID retrieves a coin by its CoinGecko ID
 func (r *coinRepository) GetByCoingeckoID(coingeckoID string) (*domain.Coin, error) {
 	var coin domain.Coin
@@ -47,6 +79,30 @@ func (r *coinRepository) GetByCoingeckoID(coingeckoID string) (*domain.Coin, err
 	return &coin, nil
 }
 
+// GetIDsByCoingeckoIDs maps CoinGecko IDs to internal coin IDs, omitting unknown coins
+func (r *coinRepository) GetIDsByCoingeckoIDs(coingeckoIDs []string) (map[string]uint, error) {
+	ids := make(map[string]uint, len(coingeckoIDs))
+	if len(coingeckoIDs) == 0 {
+		return ids, nil
+	}
+
+	var rows []struct {
+		ID          uint
+		CoingeckoID string
+	}
+	if err := r.db.Model(&domain.Coin{}).
+		Select("id, coingecko_id").
+		Where("coingecko_id IN ?", coingeckoIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin ids by coingecko_ids: %w", err)
+	}
+
+	for _, row := range rows {
+		ids[row.CoingeckoID] = row.ID
+	}
+	return ids, nil
+}
+
 // Upsert creates a new coin or updates an existing one
 func (r *coinRepository) Upsert(coin domain.Coin) error {
 	// Set CreatedAt and UpdatedAt for new records or update UpdatedAt for existing
@@ -95,10 +151,10 @@ func (r *coinRepository) UpsertBatch(coins []domain.Coin) error {
 					fully_diluted_valuation, total_volume, high_24h, low_24h, price_change_24h,
 					price_change_percentage_24h, market_cap_change_24h, market_cap_change_percentage_24h,
 					circulating_supply, total_supply, max_supply, ath, ath_change_percentage,
-					ath_date, atl, atl_change_percentage, atl_date, last_updated,
+					ath_date, atl, atl_change_percentage, atl_date, roi, last_updated,
 					created_at, updated_at, deleted_at
 				)
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29)
 				ON CONFLICT (coingecko_id)
 				DO UPDATE SET
 					symbol = EXCLUDED.symbol,
@@ -124,7 +180,9 @@ func (r *coinRepository) UpsertBatch(coins []domain.Coin) error {
 					atl = EXCLUDED.atl,
 					atl_change_percentage = EXCLUDED.atl_change_percentage,
 					atl_date = EXCLUDED.atl_date,
+					roi = EXCLUDED.roi,
 					last_updated = EXCLUDED.last_updated,
+					active = true,
 					updated_at = EXCLUDED.updated_at,
 					deleted_at = EXCLUDED.deleted_at
 			`,
@@ -132,7 +190,7 @@ func (r *coinRepository) UpsertBatch(coins []domain.Coin) error {
 				coin.FullyDilutedValuation, coin.TotalVolume, coin.High24h, coin.Low24h, coin.PriceChange24h,
 				coin.PriceChangePercentage24h, coin.MarketCapChange24h, coin.MarketCapChangePercentage24h,
 				coin.CirculatingSupply, coin.TotalSupply, coin.MaxSupply, coin.Ath, coin.AthChangePercentage,
-				coin.AthDate, coin.Atl, coin.AtlChangePercentage, coin.AtlDate, coin.LastUpdated,
+				coin.AthDate, coin.Atl, coin.AtlChangePercentage, coin.AtlDate, roiJSON(coin.ROI), coin.LastUpdated,
 				coin.CreatedAt, coin.UpdatedAt, coin.DeletedAt).Error; err != nil {
 				logger.GetLogger().WithError(err).WithField("coin_id", coin.CoingeckoID).Error("Failed to upsert coin in batch")
 				return fmt.Errorf("failed to upsert coin %s: %w", coin.CoingeckoID, err)
@@ -142,3 +200,52 @@ func (r *coinRepository) UpsertBatch(coins []domain.Coin) error {
 		return nil
 	})
 }
+
+// UpsertListed creates or updates coins from the coin list, which only carries identity and status.
+// Market figures of existing coins are kept, and coins marked delisted earlier are restored.
+func (r *coinRepository) UpsertListed(coins []domain.Coin) error {
+	if len(coins) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Select("coingecko_id", "symbol", "name", "active", "created_at", "updated_at").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "coingecko_id"}},
+			DoUpdates: append(
+				clause.AssignmentColumns([]string{"symbol", "name", "active", "updated_at"}),
+				clause.Assignment{Column: clause.Column{Name: "deleted_at"}, Value: nil},
+			),
+		}).
+		CreateInBatches(coins, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(coins)).Error("Failed to upsert listed coins")
+		return fmt.Errorf("failed to upsert listed coins: %w", err)
+	}
+	return nil
+}
+
+// roiJSON encodes a coin's ROI for the roi jsonb column of raw upserts, which bypass GORM's serializer
+func roiJSON(roi *domain.CoinROI) *string {
+	if roi == nil {
+		return nil
+	}
+	data, err := json.Marshal(roi)
+	if err != nil {
+		return nil
+	}
+	value := string(data)
+	return &value
+}
+
+// MarkDelisted soft-deletes the coins whose coingecko_id is not in listed and returns how many were marked.
+// A delisted coin comes back to life when an upsert sees it again.
+func (r *coinRepository) MarkDelisted(listed []string) (int64, error) {
+	if len(listed) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("coingecko_id NOT IN ?", listed).Delete(&domain.Coin{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark delisted coins: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -10,6 +10,8 @@ import (
 
 type CoinTickerRepository interface {
 	Upsert(t domain.CoinTicker) error
+	GetByCoinID(coinID uint) ([]domain.CoinTicker, error)
+	GetByCoinIDAndPage(coinID uint, page int) (*domain.CoinTicker, error)
 }
 
 type coinTickerRepository struct {
@@ -31,3 +33,22 @@ func (r *coinTickerRepository) Upsert(t domain.CoinTicker) error {
 	}
 	return nil
 }
+
+func (r *coinTickerRepository) GetByCoinID(coinID uint) ([]domain.CoinTicker, error) {
+	var tickers []domain.CoinTicker
+	if err := r.db.Where("coin_id = ?", coinID).Order("page").Find(&tickers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coin tickers by coin id: %w", err)
+	}
+	return tickers, nil
+}
+
+func (r *coinTickerRepository) GetByCoinIDAndPage(coinID uint, page int) (*domain.CoinTicker, error) {
+	var t domain.CoinTicker
+	if err := r.db.Where("coin_id = ? AND page = ?", coinID, page).First(&t).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get coin ticker page: %w", err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ContractLookupRepository defines the interface for cached contract address lookups
+type ContractLookupRepository interface {
+	Get(assetPlatformID, address string) (*domain.ContractLookup, error)
+	Save(lookup domain.ContractLookup) error
+}
+
+type contractLookupRepository struct {
+	db *gorm.DB
+}
+
+// NewContractLookupRepository creates a new instance of ContractLookupRepository
+func NewContractLookupRepository(db *gorm.DB) ContractLookupRepository {
+	return &contractLookupRepository{db: db}
+}
+
+// Get retrieves the cached lookup of a contract address, or nil when it was never looked up
+func (r *contractLookupRepository) Get(assetPlatformID, address string) (*domain.ContractLookup, error) {
+	var lookup domain.ContractLookup
+	if err := r.db.
+		Where("asset_platform_id = ? AND contract_address = ?", assetPlatformID, domain.NormalizeContractAddress(address)).
+		First(&lookup).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get contract lookup: %w", err)
+	}
+	return &lookup, nil
+}
+
+// Save creates or replaces the cached lookup of a contract address
+func (r *contractLookupRepository) Save(lookup domain.ContractLookup) error {
+	lookup.ContractAddress = domain.NormalizeContractAddress(lookup.ContractAddress)
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "asset_platform_id"}, {Name: "contract_address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"coingecko_id", "checked_at", "updated_at"}),
+		}).
+		Create(&lookup).Error; err != nil {
+		return fmt.Errorf("failed to save contract lookup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DerivativeContractRepository defines the interface for derivative contract data operations
+type DerivativeContractRepository interface {
+	GetAll() ([]domain.DerivativeContract, error)
+	List(opts domain.ListOptions) ([]domain.DerivativeContract, int64, error)
+	ListByExchange(derivativesExchangeID uint, opts domain.ListOptions) ([]domain.DerivativeContract, int64, error)
+	GetByMarketSymbol(market, symbol string) (*domain.DerivativeContract, error)
+	UpsertBatch(contracts []domain.DerivativeContract) error
+	MarkDelisted(notSeenSince time.Time) (int64, error)
+}
+
+type derivativeContractRepository struct {
+	db *gorm.DB
+}
+
+// NewDerivativeContractRepository creates a new instance of DerivativeContractRepository
+func NewDerivativeContractRepository(db *gorm.DB) DerivativeContractRepository {
+	return &derivativeContractRepository{db: db}
+}
+
+// GetAll retrieves all derivative contracts from the database
+func (r *derivativeContractRepository) GetAll() ([]domain.DerivativeContract, error) {
+	var contracts []domain.DerivativeContract
+	if err := r.db.Find(&contracts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get all derivative contracts: %w", err)
+	}
+	return contracts, nil
+}
+
+// List retrieves a page of derivative contracts along with the total number of contracts
+func (r *derivativeContractRepository) List(opts domain.ListOptions) ([]domain.DerivativeContract, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.DerivativeContract{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count derivative contracts: %w", err)
+	}
+
+	var contracts []domain.DerivativeContract
+	if err := paginate(r.db, opts, "open_interest_usd").Find(&contracts).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list derivative contracts: %w", err)
+	}
+	return contracts, total, nil
+}
+
+// ListByExchange retrieves a page of the derivative contracts traded on a derivatives exchange along with their total number
+func (r *derivativeContractRepository) ListByExchange(derivativesExchangeID uint, opts domain.ListOptions) ([]domain.DerivativeContract, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.DerivativeContract{}).
+		Where("derivatives_exchange_id = ?", derivativesExchangeID).
+		Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count derivative contracts by exchange: %w", err)
+	}
+
+	var contracts []domain.DerivativeContract
+	if err := paginate(r.db.Where("derivatives_exchange_id = ?", derivativesExchangeID), opts, "open_interest_usd").
+		Find(&contracts).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list derivative contracts by exchange: %w", err)
+	}
+	return contracts, total, nil
+}
+
+// GetByMarketSymbol retrieves the derivative contract with a symbol on a market, or nil when it is not stored
+func (r *derivativeContractRepository) GetByMarketSymbol(market, symbol string) (*domain.DerivativeContract, error) {
+	var contract domain.DerivativeContract
+	if err := r.db.Where("market = ? AND symbol = ?", market, symbol).First(&contract).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get derivative contract by market and symbol: %w", err)
+	}
+	return &contract, nil
+}
+
+// UpsertBatch creates or updates derivative contracts by market and symbol, restoring those marked delisted earlier
+func (r *derivativeContractRepository) UpsertBatch(contracts []domain.DerivativeContract) error {
+	if len(contracts) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "market"}, {Name: "symbol"}},
+			DoUpdates: append(
+				clause.AssignmentColumns([]string{
+					"derivatives_exchange_id", "index_id", "contract_type", "price", "price_percentage_change_24h",
+					"index", "basis", "spread", "funding_rate", "open_interest_usd", "volume_24h_usd",
+					"last_traded_at", "expired_at", "updated_at",
+				}),
+				clause.Assignment{Column: clause.Column{Name: "deleted_at"}, Value: nil},
+			),
+		}).
+		CreateInBatches(contracts, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(contracts)).Error("Failed to upsert derivative contracts")
+		return fmt.Errorf("failed to upsert derivative contracts: %w", err)
+	}
+	return nil
+}
+
+// MarkDelisted soft-deletes the derivative contracts no upsert has touched since notSeenSince, such as expired futures,
+// and returns how many were marked. A delisted contract comes back to life when an upsert sees it again.
+func (r *derivativeContractRepository) MarkDelisted(notSeenSince time.Time) (int64, error) {
+	result := r.db.Where("updated_at < ?", notSeenSince).Delete(&domain.DerivativeContract{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark delisted derivative contracts: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
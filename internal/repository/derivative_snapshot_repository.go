@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DerivativeSnapshotRepository defines the interface for derivative contract snapshots
+type DerivativeSnapshotRepository interface {
+	CreateBatch(snapshots []domain.DerivativeSnapshot) error
+	GetRange(contractID uint, from, to time.Time) ([]domain.DerivativeSnapshot, error)
+}
+
+type derivativeSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewDerivativeSnapshotRepository creates a new instance of DerivativeSnapshotRepository
+func NewDerivativeSnapshotRepository(db *gorm.DB) DerivativeSnapshotRepository {
+	return &derivativeSnapshotRepository{db: db}
+}
+
+// CreateBatch appends snapshots, leaving those already stored for the same contract and time untouched
+func (r *derivativeSnapshotRepository) CreateBatch(snapshots []domain.DerivativeSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "contract_id"}, {Name: "captured_at"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(snapshots, 500).Error; err != nil {
+		return fmt.Errorf("failed to create derivative snapshots: %w", err)
+	}
+	return nil
+}
+
+// GetRange retrieves the snapshots of a contract captured within [from, to), oldest first
+func (r *derivativeSnapshotRepository) GetRange(contractID uint, from, to time.Time) ([]domain.DerivativeSnapshot, error) {
+	var snapshots []domain.DerivativeSnapshot
+	if err := r.db.
+		Where("contract_id = ? AND captured_at >= ? AND captured_at < ?", contractID, from, to).
+		Order("captured_at ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to get derivative snapshot range: %w", err)
+	}
+	return snapshots, nil
+}
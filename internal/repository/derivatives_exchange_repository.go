@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DerivativesExchangeRepository defines the interface for derivatives exchange data operations
+type DerivativesExchangeRepository interface {
+	GetAll() ([]domain.DerivativesExchange, error)
+	List(opts domain.ListOptions) ([]domain.DerivativesExchange, int64, error)
+	GetByCoingeckoID(coingeckoID string) (*domain.DerivativesExchange, error)
+	UpsertBatch(exchanges []domain.DerivativesExchange) error
+	MarkDelisted(listed []string) (int64, error)
+}
+
+type derivativesExchangeRepository struct {
+	db *gorm.DB
+}
+
+// NewDerivativesExchangeRepository creates a new instance of DerivativesExchangeRepository
+func NewDerivativesExchangeRepository(db *gorm.DB) DerivativesExchangeRepository {
+	return &derivativesExchangeRepository{db: db}
+}
+
+// GetAll retrieves all derivatives exchanges from the database
+func (r *derivativesExchangeRepository) GetAll() ([]domain.DerivativesExchange, error) {
+	var exchanges []domain.DerivativesExchange
+	if err := r.db.Find(&exchanges).Error; err != nil {
+		return nil, fmt.Errorf("failed to get all derivatives exchanges: %w", err)
+	}
+	return exchanges, nil
+}
+
+// List retrieves a page of derivatives exchanges along with the total number of derivatives exchanges
+func (r *derivativesExchangeRepository) List(opts domain.ListOptions) ([]domain.DerivativesExchange, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.DerivativesExchange{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count derivatives exchanges: %w", err)
+	}
+
+	var exchanges []domain.DerivativesExchange
+	if err := paginate(r.db, opts, "open_interest_btc").Find(&exchanges).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list derivatives exchanges: %w", err)
+	}
+	return exchanges, total, nil
+}
+
+// GetByCoingeckoID retrieves a derivatives exchange by its CoinGecko ID, or nil when it is not stored
+func (r *derivativesExchangeRepository) GetByCoingeckoID(coingeckoID string) (*domain.DerivativesExchange, error) {
+	var exchange domain.DerivativesExchange
+	if err := r.db.Where("coingecko_id = ?", coingeckoID).First(&exchange).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get derivatives exchange by coingecko_id: %w", err)
+	}
+	return &exchange, nil
+}
+
+// UpsertBatch creates or updates derivatives exchanges, restoring those marked delisted earlier
+func (r *derivativesExchangeRepository) UpsertBatch(exchanges []domain.DerivativesExchange) error {
+	if len(exchanges) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "coingecko_id"}},
+			DoUpdates: append(
+				clause.AssignmentColumns([]string{
+					"name", "open_interest_btc", "trade_volume_24h_btc", "number_of_perpetual_pairs",
+					"number_of_futures_pairs", "year_established", "country", "description", "url", "image", "updated_at",
+				}),
+				clause.Assignment{Column: clause.Column{Name: "deleted_at"}, Value: nil},
+			),
+		}).
+		CreateInBatches(exchanges, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(exchanges)).Error("Failed to upsert derivatives exchanges")
+		return fmt.Errorf("failed to upsert derivatives exchanges: %w", err)
+	}
+	return nil
+}
+
+// MarkDelisted soft-deletes the derivatives exchanges whose coingecko_id is not in listed and returns how many were
+// marked. A delisted exchange comes back to life when an upsert sees it again.
+func (r *derivativesExchangeRepository) MarkDelisted(listed []string) (int64, error) {
+	if len(listed) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("coingecko_id NOT IN ?", listed).Delete(&domain.DerivativesExchange{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark delisted derivatives exchanges: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
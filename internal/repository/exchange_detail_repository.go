@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ExchangeDetailRepository defines the interface for stored /exchanges/{id} payloads
+type ExchangeDetailRepository interface {
+	Upsert(detail domain.ExchangeDetail) error
+	GetByExchangeID(exchangeID uint) (*domain.ExchangeDetail, error)
+}
+
+type exchangeDetailRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeDetailRepository creates a new instance of ExchangeDetailRepository
+func NewExchangeDetailRepository(db *gorm.DB) ExchangeDetailRepository {
+	return &exchangeDetailRepository{db: db}
+}
+
+// Upsert creates the detail of an exchange or replaces the stored one
+func (r *exchangeDetailRepository) Upsert(detail domain.ExchangeDetail) error {
+	if detail.CreatedAt.IsZero() {
+		detail.CreatedAt = time.Now()
+	}
+	detail.UpdatedAt = time.Now()
+	if err := r.db.Where(domain.ExchangeDetail{ExchangeID: detail.ExchangeID}).Assign(detail).FirstOrCreate(&detail).Error; err != nil {
+		return fmt.Errorf("failed to upsert exchange detail: %w", err)
+	}
+	return nil
+}
+
+// GetByExchangeID retrieves the stored detail of an exchange, or nil when it has not been fetched
+func (r *exchangeDetailRepository) GetByExchangeID(exchangeID uint) (*domain.ExchangeDetail, error) {
+	var d domain.ExchangeDetail
+	if err := r.db.Where("exchange_id = ?", exchangeID).First(&d).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get exchange detail by exchange id: %w", err)
+	}
+	return &d, nil
+}
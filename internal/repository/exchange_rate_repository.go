@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExchangeRateRepository defines the interface for BTC exchange rate snapshots
+type ExchangeRateRepository interface {
+	CreateBatch(rates []domain.ExchangeRate) error
+	GetAt(at time.Time) ([]domain.ExchangeRate, error)
+	GetRange(currency string, from, to time.Time) ([]domain.ExchangeRate, error)
+}
+
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeRateRepository creates a new instance of ExchangeRateRepository
+func NewExchangeRateRepository(db *gorm.DB) ExchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+// CreateBatch inserts the rates of a snapshot, leaving rates that are already stored untouched
+func (r *exchangeRateRepository) CreateBatch(rates []domain.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "captured_at"}, {Name: "currency"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(rates, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(rates)).Error("Failed to create exchange rates batch")
+		return fmt.Errorf("failed to create exchange rates batch: %w", err)
+	}
+	return nil
+}
+
+// GetAt retrieves the latest snapshot captured at or before the given time, ordered by currency.
+// Returns an empty slice when nothing was captured by then.
+func (r *exchangeRateRepository) GetAt(at time.Time) ([]domain.ExchangeRate, error) {
+	latest := r.db.Model(&domain.ExchangeRate{}).Select("MAX(captured_at)").Where("captured_at <= ?", at)
+
+	var rates []domain.ExchangeRate
+	if err := r.db.
+		Where("captured_at = (?)", latest).
+		Order("currency").
+		Find(&rates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	return rates, nil
+}
+
+// GetRange retrieves the rates of a currency captured within [from, to), oldest first
+func (r *exchangeRateRepository) GetRange(currency string, from, to time.Time) ([]domain.ExchangeRate, error) {
+	var rates []domain.ExchangeRate
+	if err := r.db.
+		Where("currency = ? AND captured_at >= ? AND captured_at < ?", currency, from, to).
+		Order("captured_at ASC").
+		Find(&rates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate range: %w", err)
+	}
+	return rates, nil
+}
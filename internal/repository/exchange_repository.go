@@ -12,8 +12,11 @@ import (
 // ExchangeRepository defines the interface for exchange data operations
 type ExchangeRepository interface {
 	GetAll() ([]domain.Exchange, error)
+	List(opts domain.ListOptions) ([]domain.Exchange, int64, error)
+	GetByCoingeckoID(coingeckoID string) (*domain.Exchange, error)
 	Upsert(exchange domain.Exchange) error
 	UpsertBatch(exchanges []domain.Exchange) error
+	MarkDelisted(listed []string) (int64, error)
 }
 
 type exchangeRepository struct {
@@ -34,6 +37,32 @@ func (r *exchangeRepository) GetAll() ([]domain.Exchange, error) {
 	return exchanges, nil
 }
 
+// List retrieves a page of exchanges along with the total number of exchanges
+func (r *exchangeRepository) List(opts domain.ListOptions) ([]domain.Exchange, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.Exchange{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count exchanges: %w", err)
+	}
+
+	var exchanges []domain.Exchange
+	if err := paginate(r.db, opts, "trust_score_rank").Find(&exchanges).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list exchanges: %w", err)
+	}
+	return exchanges, total, nil
+}
+
+// GetByCoingeckoID retrieves an exchange by its CoinGecko ID
+func (r *exchangeRepository) GetByCoingeckoID(coingeckoID string) (*domain.Exchange, error) {
+	var exchange domain.Exchange
+	if err := r.db.Where("coingecko_id = ?", coingeckoID).First(&exchange).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get exchange by coingecko_id: %w", err)
+	}
+	return &exchange, nil
+}
+
 // Upsert creates a new exchange or updates an existing one
 func (r *exchangeRepository) Upsert(exchange domain.Exchange) error {
 	// Set CreatedAt and UpdatedAt for new records or update UpdatedAt for existing
@@ -111,3 +140,16 @@ func (r *exchangeRepository) UpsertBatch(exchanges []domain.Exchange) error {
 		return nil
 	})
 }
+
+// MarkDelisted soft-deletes the exchanges whose coingecko_id is not in listed and returns how many were marked.
+// A delisted exchange comes back to life when an upsert sees it again.
+func (r *exchangeRepository) MarkDelisted(listed []string) (int64, error) {
+	if len(listed) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("coingecko_id NOT IN ?", listed).Delete(&domain.Exchange{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark delisted exchanges: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
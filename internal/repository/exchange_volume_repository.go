@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExchangeVolumeRepository defines the interface for exchange volume chart points
+type ExchangeVolumeRepository interface {
+	CreateBatch(points []domain.ExchangeVolumePoint) error
+	GetLatestTimestamp(exchangeID uint) (*time.Time, error)
+	GetRange(exchangeID uint, from, to time.Time) ([]domain.ExchangeVolumePoint, error)
+}
+
+type exchangeVolumeRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeVolumeRepository creates a new instance of ExchangeVolumeRepository
+func NewExchangeVolumeRepository(db *gorm.DB) ExchangeVolumeRepository {
+	return &exchangeVolumeRepository{db: db}
+}
+
+// CreateBatch inserts volume points, leaving days that are already stored untouched
+func (r *exchangeVolumeRepository) CreateBatch(points []domain.ExchangeVolumePoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "exchange_id"}, {Name: "timestamp"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(points, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(points)).Error("Failed to create exchange volume points batch")
+		return fmt.Errorf("failed to create exchange volume points batch: %w", err)
+	}
+
+	logger.GetLogger().WithField("count", len(points)).Debug("Successfully created exchange volume points batch")
+	return nil
+}
+
+// GetLatestTimestamp returns the day of the newest stored point of an exchange, or nil when none is stored
+func (r *exchangeVolumeRepository) GetLatestTimestamp(exchangeID uint) (*time.Time, error) {
+	var point domain.ExchangeVolumePoint
+	if err := r.db.
+		Where("exchange_id = ?", exchangeID).
+		Order("timestamp DESC").
+		First(&point).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest exchange volume timestamp: %w", err)
+	}
+	timestamp := point.Timestamp.UTC()
+	return &timestamp, nil
+}
+
+// GetRange retrieves the volume points of an exchange within [from, to), oldest first
+func (r *exchangeVolumeRepository) GetRange(exchangeID uint, from, to time.Time) ([]domain.ExchangeVolumePoint, error) {
+	var points []domain.ExchangeVolumePoint
+	if err := r.db.
+		Where("exchange_id = ? AND timestamp >= ? AND timestamp < ?", exchangeID, from, to).
+		Order("timestamp ASC").
+		Find(&points).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exchange volume range: %w", err)
+	}
+	return points, nil
+}
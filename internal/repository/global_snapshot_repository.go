@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GlobalSnapshotRepository defines the interface for market-wide snapshots
+type GlobalSnapshotRepository interface {
+	Create(snapshot *domain.GlobalSnapshot) error
+	GetLatest() (*domain.GlobalSnapshot, error)
+	GetRange(from, to time.Time) ([]domain.GlobalSnapshot, error)
+}
+
+type globalSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewGlobalSnapshotRepository creates a new instance of GlobalSnapshotRepository
+func NewGlobalSnapshotRepository(db *gorm.DB) GlobalSnapshotRepository {
+	return &globalSnapshotRepository{db: db}
+}
+
+// Create appends a snapshot
+func (r *globalSnapshotRepository) Create(snapshot *domain.GlobalSnapshot) error {
+	if err := r.db.Create(snapshot).Error; err != nil {
+		return fmt.Errorf("failed to create global snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetLatest retrieves the most recent snapshot, or nil when none was captured yet
+func (r *globalSnapshotRepository) GetLatest() (*domain.GlobalSnapshot, error) {
+	var snapshot domain.GlobalSnapshot
+	if err := r.db.Order("captured_at DESC").First(&snapshot).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest global snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// GetRange retrieves the snapshots captured within [from, to), oldest first
+func (r *globalSnapshotRepository) GetRange(from, to time.Time) ([]domain.GlobalSnapshot, error) {
+	var snapshots []domain.GlobalSnapshot
+	if err := r.db.
+		Where("captured_at >= ? AND captured_at < ?", from, to).
+		Order("captured_at ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to get global snapshot range: %w", err)
+	}
+	return snapshots, nil
+}
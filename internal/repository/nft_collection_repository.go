@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NFTCollectionRepository defines the interface for NFT collection data operations
+type NFTCollectionRepository interface {
+	GetAll() ([]domain.NFTCollection, error)
+	List(opts domain.ListOptions) ([]domain.NFTCollection, int64, error)
+	ListByPlatform(assetPlatformID string, opts domain.ListOptions) ([]domain.NFTCollection, int64, error)
+	GetByCoingeckoID(coingeckoID string) (*domain.NFTCollection, error)
+	GetByContract(assetPlatformID, address string) (*domain.NFTCollection, error)
+	UpsertListed(collections []domain.NFTCollection) error
+	UpsertDetail(collection *domain.NFTCollection) error
+	MarkDelisted(listed []string) (int64, error)
+}
+
+type nftCollectionRepository struct {
+	db *gorm.DB
+}
+
+// NewNFTCollectionRepository creates a new instance of NFTCollectionRepository
+func NewNFTCollectionRepository(db *gorm.DB) NFTCollectionRepository {
+	return &nftCollectionRepository{db: db}
+}
+
+// GetAll retrieves all NFT collections from the database
+func (r *nftCollectionRepository) GetAll() ([]domain.NFTCollection, error) {
+	var collections []domain.NFTCollection
+	if err := r.db.Omit("raw_json").Find(&collections).Error; err != nil {
+		return nil, fmt.Errorf("failed to get all NFT collections: %w", err)
+	}
+	return collections, nil
+}
+
+// List retrieves a page of NFT collections along with the total number of collections
+func (r *nftCollectionRepository) List(opts domain.ListOptions) ([]domain.NFTCollection, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.NFTCollection{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count NFT collections: %w", err)
+	}
+
+	var collections []domain.NFTCollection
+	if err := paginate(r.db.Omit("raw_json"), opts, "market_cap_usd").Find(&collections).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list NFT collections: %w", err)
+	}
+	return collections, total, nil
+}
+
+// ListByPlatform retrieves a page of the NFT collections deployed on an asset platform along with their total number
+func (r *nftCollectionRepository) ListByPlatform(assetPlatformID string, opts domain.ListOptions) ([]domain.NFTCollection, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.NFTCollection{}).Where("asset_platform_id = ?", assetPlatformID).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count NFT collections by platform: %w", err)
+	}
+
+	var collections []domain.NFTCollection
+	if err := paginate(r.db.Omit("raw_json").Where("asset_platform_id = ?", assetPlatformID), opts, "market_cap_usd").
+		Find(&collections).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list NFT collections by platform: %w", err)
+	}
+	return collections, total, nil
+}
+
+// GetByCoingeckoID retrieves an NFT collection by its CoinGecko ID, or nil when it is not stored
+func (r *nftCollectionRepository) GetByCoingeckoID(coingeckoID string) (*domain.NFTCollection, error) {
+	var collection domain.NFTCollection
+	if err := r.db.Where("coingecko_id = ?", coingeckoID).First(&collection).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get NFT collection by coingecko_id: %w", err)
+	}
+	return &collection, nil
+}
+
+// GetByContract retrieves the NFT collection deployed at a contract address on an asset platform,
+// or nil when it is not stored
+func (r *nftCollectionRepository) GetByContract(assetPlatformID, address string) (*domain.NFTCollection, error) {
+	var collection domain.NFTCollection
+	if err := r.db.
+		Where("asset_platform_id = ? AND contract_address = ?", assetPlatformID, domain.NormalizeContractAddress(address)).
+		Order("market_cap_usd DESC NULLS LAST, id").
+		First(&collection).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get NFT collection by contract address: %w", err)
+	}
+	return &collection, nil
+}
+
+// UpsertListed creates or updates NFT collections from /nfts/list, which only carries identity and contract.
+// Details and market figures of existing collections are kept, and collections marked delisted earlier are restored.
+func (r *nftCollectionRepository) UpsertListed(collections []domain.NFTCollection) error {
+	if len(collections) == 0 {
+		return nil
+	}
+	for i := range collections {
+		collections[i].ContractAddress = normalizeOptionalAddress(collections[i].ContractAddress)
+	}
+
+	if err := r.db.
+		Select("coingecko_id", "asset_platform_id", "contract_address", "name", "symbol", "created_at", "updated_at").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "coingecko_id"}},
+			DoUpdates: append(
+				clause.AssignmentColumns([]string{"asset_platform_id", "contract_address", "name", "symbol", "updated_at"}),
+				clause.Assignment{Column: clause.Column{Name: "deleted_at"}, Value: nil},
+			),
+		}).
+		CreateInBatches(collections, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(collections)).Error("Failed to upsert listed NFT collections")
+		return fmt.Errorf("failed to upsert listed NFT collections: %w", err)
+	}
+	return nil
+}
+
+// UpsertDetail creates or updates an NFT collection together with the fields taken from its /nfts/{id} payload.
+// The stored ID is set on the collection.
+func (r *nftCollectionRepository) UpsertDetail(collection *domain.NFTCollection) error {
+	collection.ContractAddress = normalizeOptionalAddress(collection.ContractAddress)
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "coingecko_id"}},
+			DoUpdates: append(
+				clause.AssignmentColumns([]string{
+					"asset_platform_id", "contract_address", "name", "symbol",
+					"raw_json", "description", "image", "native_currency", "native_currency_symbol",
+					"floor_price_usd", "market_cap_usd", "volume_24h_usd", "number_of_unique_addresses", "total_supply",
+					"detail_fetched_at", "updated_at",
+				}),
+				clause.Assignment{Column: clause.Column{Name: "deleted_at"}, Value: nil},
+			),
+		}).
+		Create(collection).Error; err != nil {
+		return fmt.Errorf("failed to upsert NFT collection detail: %w", err)
+	}
+	return nil
+}
+
+// MarkDelisted soft-deletes the NFT collections whose coingecko_id is not in listed and returns how many were marked.
+// A delisted collection comes back to life when an upsert sees it again.
+func (r *nftCollectionRepository) MarkDelisted(listed []string) (int64, error) {
+	if len(listed) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("coingecko_id NOT IN ?", listed).Delete(&domain.NFTCollection{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark delisted NFT collections: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// normalizeOptionalAddress normalizes a contract address that may be missing
+func normalizeOptionalAddress(address *string) *string {
+	if address == nil {
+		return nil
+	}
+	normalized := domain.NormalizeContractAddress(*address)
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
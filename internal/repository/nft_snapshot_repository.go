@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NFTSnapshotRepository defines the interface for NFT collection market snapshots
+type NFTSnapshotRepository interface {
+	Create(snapshot *domain.NFTSnapshot) error
+	GetRange(collectionID uint, from, to time.Time) ([]domain.NFTSnapshot, error)
+}
+
+type nftSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewNFTSnapshotRepository creates a new instance of NFTSnapshotRepository
+func NewNFTSnapshotRepository(db *gorm.DB) NFTSnapshotRepository {
+	return &nftSnapshotRepository{db: db}
+}
+
+// Create appends a snapshot, leaving one already stored for the same collection and time untouched
+func (r *nftSnapshotRepository) Create(snapshot *domain.NFTSnapshot) error {
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "collection_id"}, {Name: "captured_at"}},
+			DoNothing: true,
+		}).
+		Create(snapshot).Error; err != nil {
+		return fmt.Errorf("failed to create NFT snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetRange retrieves the snapshots of a collection captured within [from, to), oldest first
+func (r *nftSnapshotRepository) GetRange(collectionID uint, from, to time.Time) ([]domain.NFTSnapshot, error) {
+	var snapshots []domain.NFTSnapshot
+	if err := r.db.
+		Where("collection_id = ? AND captured_at >= ? AND captured_at < ?", collectionID, from, to).
+		Order("captured_at ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to get NFT snapshot range: %w", err)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// paginate applies ordering, offset and limit from the list options to a query.
+// Callers are expected to pass a whitelisted SortBy column.
+func paginate(db *gorm.DB, opts domain.ListOptions, defaultSort string) *gorm.DB {
+	opts = opts.Normalize()
+
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = defaultSort
+	}
+
+	direction := "ASC"
+	if opts.SortDesc {
+		direction = "DESC"
+	}
+
+	return db.
+		Order(clause.OrderBy{
+			// Tie-break on the primary key so pages stay stable between requests
+			Expression: clause.Expr{
+				SQL:  "? " + direction + " NULLS LAST, ?",
+				Vars: []interface{}{clause.Column{Name: sortBy}, clause.Column{Name: "id"}},
+			},
+		}).
+		Offset(opts.Offset()).
+		Limit(opts.PerPage)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SupportedVsCurrencyRepository defines the interface for the list of supported quote currencies
+type SupportedVsCurrencyRepository interface {
+	GetAll() ([]string, error)
+	ReplaceAll(codes []string) error
+}
+
+type supportedVsCurrencyRepository struct {
+	db *gorm.DB
+}
+
+// NewSupportedVsCurrencyRepository creates a new instance of SupportedVsCurrencyRepository
+func NewSupportedVsCurrencyRepository(db *gorm.DB) SupportedVsCurrencyRepository {
+	return &supportedVsCurrencyRepository{db: db}
+}
+
+// GetAll retrieves every supported currency code in alphabetical order
+func (r *supportedVsCurrencyRepository) GetAll() ([]string, error) {
+	var codes []string
+	if err := r.db.Model(&domain.SupportedVsCurrency{}).Order("code").Pluck("code", &codes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get supported vs currencies: %w", err)
+	}
+	return codes, nil
+}
+
+// ReplaceAll makes the stored list exactly the given codes; an empty list is rejected
+func (r *supportedVsCurrencyRepository) ReplaceAll(codes []string) error {
+	if len(codes) == 0 {
+		return fmt.Errorf("no supported vs currencies to store")
+	}
+
+	rows := make([]domain.SupportedVsCurrency, len(codes))
+	for i, code := range codes {
+		rows[i] = domain.SupportedVsCurrency{Code: code}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("code NOT IN ?", codes).Delete(&domain.SupportedVsCurrency{}).Error; err != nil {
+			return fmt.Errorf("failed to delete unsupported vs currencies: %w", err)
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
+		}).Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to store supported vs currencies: %w", err)
+		}
+		return nil
+	})
+}
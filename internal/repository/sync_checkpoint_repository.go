@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SyncCheckpointRepository defines the interface for resumable sync checkpoints
+type SyncCheckpointRepository interface {
+	Get(kind string) (*domain.SyncCheckpoint, error)
+	Save(checkpoint domain.SyncCheckpoint) error
+	Delete(kind string) error
+}
+
+type syncCheckpointRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncCheckpointRepository creates a new instance of SyncCheckpointRepository
+func NewSyncCheckpointRepository(db *gorm.DB) SyncCheckpointRepository {
+	return &syncCheckpointRepository{db: db}
+}
+
+// Get retrieves the checkpoint of a sync kind, or nil when the last run completed
+func (r *syncCheckpointRepository) Get(kind string) (*domain.SyncCheckpoint, error) {
+	var checkpoint domain.SyncCheckpoint
+	if err := r.db.Where("kind = ?", kind).First(&checkpoint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get sync checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Save creates or replaces the checkpoint of a sync kind
+func (r *syncCheckpointRepository) Save(checkpoint domain.SyncCheckpoint) error {
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "kind"}},
+			DoUpdates: clause.AssignmentColumns([]string{"coingecko_id", "page", "updated_at"}),
+		}).
+		Create(&checkpoint).Error; err != nil {
+		return fmt.Errorf("failed to save sync checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the checkpoint of a sync kind so the next run starts from the beginning
+func (r *syncCheckpointRepository) Delete(kind string) error {
+	if err := r.db.Where("kind = ?", kind).Delete(&domain.SyncCheckpoint{}).Error; err != nil {
+		return fmt.Errorf("failed to delete sync checkpoint: %w", err)
+	}
+	return nil
+}
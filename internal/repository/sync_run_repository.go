@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// SyncRunRepository defines the interface for the sync run journal
+type SyncRunRepository interface {
+	Create(run *domain.SyncRun) error
+	Update(run *domain.SyncRun) error
+	ListRecent(kind string, limit int) ([]domain.SyncRun, error)
+	GetLatestSucceeded() ([]domain.SyncRun, error)
+}
+
+type syncRunRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncRunRepository creates a new instance of SyncRunRepository
+func NewSyncRunRepository(db *gorm.DB) SyncRunRepository {
+	return &syncRunRepository{db: db}
+}
+
+// Create inserts a new sync run and sets its ID
+func (r *syncRunRepository) Create(run *domain.SyncRun) error {
+	if err := r.db.Create(run).Error; err != nil {
+		return fmt.Errorf("failed to create sync run: %w", err)
+	}
+	return nil
+}
+
+// Update saves the current state of a sync run
+func (r *syncRunRepository) Update(run *domain.SyncRun) error {
+	if err := r.db.Save(run).Error; err != nil {
+		return fmt.Errorf("failed to update sync run %d: %w", run.ID, err)
+	}
+	return nil
+}
+
+// ListRecent retrieves the most recent sync runs, newest first, optionally limited to one kind
+func (r *syncRunRepository) ListRecent(kind string, limit int) ([]domain.SyncRun, error) {
+	query := r.db.Order("started_at DESC, id DESC").Limit(limit)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	var runs []domain.SyncRun
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list sync runs: %w", err)
+	}
+	return runs, nil
+}
+
+// GetLatestSucceeded retrieves the latest successful run of every kind, ordered by kind
+func (r *syncRunRepository) GetLatestSucceeded() ([]domain.SyncRun, error) {
+	var runs []domain.SyncRun
+	if err := r.db.Raw(`
+		SELECT DISTINCT ON (kind) *
+		FROM sync_runs
+		WHERE status = ?
+		ORDER BY kind, started_at DESC
+	`, domain.SyncStatusSucceeded).Scan(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get latest successful sync runs: %w", err)
+	}
+	return runs, nil
+}
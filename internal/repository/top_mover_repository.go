@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TopMoverRepository defines the interface for top gainers and losers snapshots
+type TopMoverRepository interface {
+	CreateBatch(movers []domain.TopMover) error
+	GetAt(at time.Time) ([]domain.TopMover, error)
+}
+
+type topMoverRepository struct {
+	db *gorm.DB
+}
+
+// NewTopMoverRepository creates a new instance of TopMoverRepository
+func NewTopMoverRepository(db *gorm.DB) TopMoverRepository {
+	return &topMoverRepository{db: db}
+}
+
+// CreateBatch inserts the entries of a snapshot, leaving entries that are already stored untouched
+func (r *topMoverRepository) CreateBatch(movers []domain.TopMover) error {
+	if len(movers) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "captured_at"}, {Name: "direction"}, {Name: "rank"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(movers, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(movers)).Error("Failed to create top movers batch")
+		return fmt.Errorf("failed to create top movers batch: %w", err)
+	}
+
+	logger.GetLogger().WithField("count", len(movers)).Debug("Successfully created top movers batch")
+	return nil
+}
+
+// GetAt retrieves the latest snapshot captured at or before the given time, ordered by direction and rank.
+// Returns an empty slice when nothing was captured by then.
+func (r *topMoverRepository) GetAt(at time.Time) ([]domain.TopMover, error) {
+	latest := r.db.Model(&domain.TopMover{}).Select("MAX(captured_at)").Where("captured_at <= ?", at)
+
+	var movers []domain.TopMover
+	if err := r.db.
+		Where("captured_at = (?)", latest).
+		Order("direction, rank").
+		Find(&movers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get top movers: %w", err)
+	}
+	return movers, nil
+}
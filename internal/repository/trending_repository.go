@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TrendingRepository defines the interface for trending list snapshots
+type TrendingRepository interface {
+	CreateBatch(items []domain.TrendingItem) error
+	GetAt(at time.Time) ([]domain.TrendingItem, error)
+	Summarize(kind string, from, to time.Time) ([]domain.TrendingSummary, error)
+}
+
+type trendingRepository struct {
+	db *gorm.DB
+}
+
+// NewTrendingRepository creates a new instance of TrendingRepository
+func NewTrendingRepository(db *gorm.DB) TrendingRepository {
+	return &trendingRepository{db: db}
+}
+
+// CreateBatch inserts the items of a snapshot, leaving entries that are already stored untouched
+func (r *trendingRepository) CreateBatch(items []domain.TrendingItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "captured_at"}, {Name: "kind"}, {Name: "rank"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(items, 500).Error; err != nil {
+		logger.GetLogger().WithError(err).WithField("count", len(items)).Error("Failed to create trending items batch")
+		return fmt.Errorf("failed to create trending items batch: %w", err)
+	}
+
+	logger.GetLogger().WithField("count", len(items)).Debug("Successfully created trending items batch")
+	return nil
+}
+
+// GetAt retrieves the latest snapshot captured at or before the given time, ordered by kind and rank.
+// Returns an empty slice when nothing was captured by then.
+func (r *trendingRepository) GetAt(at time.Time) ([]domain.TrendingItem, error) {
+	latest := r.db.Model(&domain.TrendingItem{}).Select("MAX(captured_at)").Where("captured_at <= ?", at)
+
+	var items []domain.TrendingItem
+	if err := r.db.
+		Where("captured_at = (?)", latest).
+		Order("kind, rank").
+		Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to get trending items: %w", err)
+	}
+	return items, nil
+}
+
+// Summarize aggregates the entries of a kind captured within [from, to), most often listed first.
+// Returns an empty slice when nothing was captured in the range.
+func (r *trendingRepository) Summarize(kind string, from, to time.Time) ([]domain.TrendingSummary, error) {
+	// Scan leaves a nil slice alone when no rows match, which would serialize as null
+	summaries := make([]domain.TrendingSummary, 0)
+	if err := r.db.Model(&domain.TrendingItem{}).
+		Select(`coingecko_id, MAX(symbol) AS symbol, MAX(name) AS name, COUNT(*) AS snapshots,
+			MIN(rank) AS best_rank, MIN(captured_at) AS first_seen, MAX(captured_at) AS last_seen`).
+		Where("kind = ? AND captured_at >= ? AND captured_at < ?", kind, from, to).
+		Group("coingecko_id").
+		Order("snapshots DESC, best_rank, coingecko_id").
+		Scan(&summaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to summarize trending items: %w", err)
+	}
+	return summaries, nil
+}
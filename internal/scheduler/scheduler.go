@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cgoffline/pkg/logger"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Job is a named unit of work run on its own schedule
+type Job struct {
+	Name string
+	// Schedule is a standard 5-field cron expression, a descriptor such as
+	// "@hourly" or "@every 10m", or a plain Go duration like "15m".
+	// An empty schedule or "off" disables the job.
+	Schedule string
+	Run      func(ctx context.Context) error
+}
+
+// scheduledJob tracks whether a job is currently executing so runs never overlap
+type scheduledJob struct {
+	Job
+	running atomic.Bool
+}
+
+// Scheduler runs jobs periodically and cancels them on shutdown
+type Scheduler struct {
+	cron *cron.Cron
+	jobs []*scheduledJob
+	wg   sync.WaitGroup // start-up runs; cron tracks the runs it triggers
+	ctx  context.Context
+}
+
+// New creates an empty scheduler
+func New() *Scheduler {
+	return &Scheduler{
+		cron: cron.New(cron.WithLocation(time.UTC)),
+	}
+}
+
+// ParseSchedule converts a schedule string into a cron schedule.
+// It returns nil when the schedule is disabled.
+func ParseSchedule(spec string) (cron.Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || strings.EqualFold(spec, "off") {
+		return nil, nil
+	}
+
+	// Accept bare durations as a shorthand for "@every <duration>"
+	if d, err := time.ParseDuration(spec); err == nil {
+		if d <= 0 {
+			return nil, fmt.Errorf("interval must be positive: %s", spec)
+		}
+		return cron.Every(d), nil
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	return schedule, nil
+}
+
+// Add registers a job. Disabled jobs are logged and ignored.
+func (s *Scheduler) Add(job Job) error {
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("failed to schedule %s: %w", job.Name, err)
+	}
+	if schedule == nil {
+		logger.GetLogger().WithField("job", job.Name).Info("Scheduled job disabled")
+		return nil
+	}
+
+	sj := &scheduledJob{Job: job}
+	s.jobs = append(s.jobs, sj)
+	s.cron.Schedule(schedule, cron.FuncJob(func() { s.run(sj) }))
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"job":      job.Name,
+		"schedule": job.Schedule,
+	}).Info("Scheduled job registered")
+	return nil
+}
+
+// Start begins running jobs with the given context. When runOnStart is set,
+// every job is also triggered once immediately instead of waiting for its first tick.
+func (s *Scheduler) Start(ctx context.Context, runOnStart bool) {
+	s.ctx = ctx
+
+	if runOnStart {
+		for _, sj := range s.jobs {
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.run(sj)
+			}()
+		}
+	}
+
+	s.cron.Start()
+	logger.GetLogger().WithField("jobs", len(s.jobs)).Info("Scheduler started")
+}
+
+// Stop prevents new runs and waits for in-flight jobs to return.
+// Callers should cancel the context passed to Start first so running syncs abort promptly.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		// cron waits for the runs it triggered; the wait group covers start-up runs
+		<-s.cron.Stop().Done()
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.GetLogger().Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for scheduled jobs: %w", ctx.Err())
+	}
+}
+
+// run executes a job unless a previous run of the same job is still in flight
+func (s *Scheduler) run(sj *scheduledJob) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	if !sj.running.CompareAndSwap(false, true) {
+		logger.GetLogger().WithField("job", sj.Name).Warn("Previous run still in progress; skipping")
+		return
+	}
+
+	defer sj.running.Store(false)
+
+	start := time.Now()
+	logger.GetLogger().WithField("job", sj.Name).Info("Scheduled job started")
+
+	if err := sj.Run(s.ctx); err != nil {
+		if s.ctx.Err() != nil {
+			logger.GetLogger().WithError(err).WithField("job", sj.Name).Warn("Scheduled job interrupted by shutdown")
+			return
+		}
+		logger.GetLogger().WithError(err).WithFields(map[string]interface{}{
+			"job":      sj.Name,
+			"duration": time.Since(start).String(),
+		}).Error("Scheduled job failed")
+		return
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"job":      sj.Name,
+		"duration": time.Since(start).String(),
+	}).Info("Scheduled job completed")
+}
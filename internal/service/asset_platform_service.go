@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"cgoffline/internal/domain"
 	"cgoffline/pkg/logger"
@@ -13,21 +12,20 @@ import (
 type assetPlatformService struct {
 	repository domain.AssetPlatformRepository
 	apiClient  *CoinGeckoClient
+	journal    *SyncJournal
 }
 
 // NewAssetPlatformService creates a new asset platform service
-func NewAssetPlatformService(repository domain.AssetPlatformRepository, apiClient *CoinGeckoClient) domain.AssetPlatformService {
+func NewAssetPlatformService(repository domain.AssetPlatformRepository, apiClient *CoinGeckoClient, journal *SyncJournal) domain.AssetPlatformService {
 	return &assetPlatformService{
 		repository: repository,
 		apiClient:  apiClient,
+		journal:    journal,
 	}
 }
 
 // FetchAndStoreAssetPlatforms fetches asset platforms from CoinGecko API and stores them in the database
-func (s *assetPlatformService) FetchAndStoreAssetPlatforms() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
+func (s *assetPlatformService) FetchAndStoreAssetPlatforms(ctx context.Context) error {
 	logger.GetLogger().Info("Starting to fetch and store asset platforms")
 
 	// Check API health first
@@ -53,6 +51,7 @@ func (s *assetPlatformService) FetchAndStoreAssetPlatforms() error {
 		logger.GetLogger().WithError(err).Error("Failed to store asset platforms in database")
 		return fmt.Errorf("failed to store asset platforms: %w", err)
 	}
+	syncRunFromContext(ctx).addWritten(len(platforms))
 
 	logger.GetLogger().WithField("count", len(platforms)).Info("Successfully fetched and stored asset platforms")
 	return nil
@@ -88,11 +87,15 @@ func (s *assetPlatformService) GetAssetPlatformByID(id string) (*domain.AssetPla
 
 // SyncAssetPlatforms synchronizes asset platforms with the CoinGecko API
 // This method fetches fresh data and updates the database
-func (s *assetPlatformService) SyncAssetPlatforms() error {
+func (s *assetPlatformService) SyncAssetPlatforms(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindAssetPlatforms)
+	defer func() { run.finish(err) }()
+
 	logger.GetLogger().Info("Starting asset platforms synchronization")
 
 	// Get current count from database
 	currentPlatforms, err := s.repository.GetAll()
+	countsKnown := err == nil
 	if err != nil {
 		logger.GetLogger().WithError(err).Warn("Failed to get current platform count, proceeding with sync")
 	} else {
@@ -100,7 +103,7 @@ func (s *assetPlatformService) SyncAssetPlatforms() error {
 	}
 
 	// Fetch and store fresh data
-	if err := s.FetchAndStoreAssetPlatforms(); err != nil {
+	if err := s.FetchAndStoreAssetPlatforms(ctx); err != nil {
 		return fmt.Errorf("failed to sync asset platforms: %w", err)
 	}
 
@@ -110,6 +113,9 @@ func (s *assetPlatformService) SyncAssetPlatforms() error {
 		logger.GetLogger().WithError(err).Warn("Failed to get updated platform count")
 	} else {
 		logger.GetLogger().WithField("updated_count", len(updatedPlatforms)).Info("Asset platforms after synchronization")
+		if countsKnown {
+			run.addInserted(len(updatedPlatforms) - len(currentPlatforms))
+		}
 	}
 
 	logger.GetLogger().Info("Asset platforms synchronization completed successfully")
@@ -3,31 +3,39 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
 	"cgoffline/pkg/logger"
 )
 
 // coinCategoryService implements the CoinCategoryService interface
 type coinCategoryService struct {
-	repository domain.CoinCategoryRepository
-	apiClient  *CoinGeckoClient
+	repository   domain.CoinCategoryRepository
+	snapshotRepo repository.CategorySnapshotRepository
+	apiClient    *CoinGeckoClient
+	journal      *SyncJournal
 }
 
 // NewCoinCategoryService creates a new coin category service
-func NewCoinCategoryService(repository domain.CoinCategoryRepository, apiClient *CoinGeckoClient) domain.CoinCategoryService {
+func NewCoinCategoryService(
+	repository domain.CoinCategoryRepository,
+	snapshotRepo repository.CategorySnapshotRepository,
+	apiClient *CoinGeckoClient,
+	journal *SyncJournal,
+) domain.CoinCategoryService {
 	return &coinCategoryService{
-		repository: repository,
-		apiClient:  apiClient,
+		repository:   repository,
+		snapshotRepo: snapshotRepo,
+		apiClient:    apiClient,
+		journal:      journal,
 	}
 }
 
 // FetchAndStoreCoinCategories fetches coin categories from CoinGecko API and stores them in the database
-func (s *coinCategoryService) FetchAndStoreCoinCategories() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
+func (s *coinCategoryService) FetchAndStoreCoinCategories(ctx context.Context) error {
 	logger.GetLogger().Info("Starting to fetch and store coin categories")
 
 	// Check API health first
@@ -48,11 +56,29 @@ func (s *coinCategoryService) FetchAndStoreCoinCategories() error {
 		return fmt.Errorf("no coin categories received from API")
 	}
 
+	// Count what is stored now so a suspiciously short answer does not delist most categories
+	stored, err := s.repository.GetAll()
+	if err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to get current coin categories from database")
+		return fmt.Errorf("failed to get current coin categories: %w", err)
+	}
+
 	// Store categories in database using upsert to handle updates
 	if err := s.repository.UpsertBatch(categories); err != nil {
 		logger.GetLogger().WithError(err).Error("Failed to store coin categories in database")
 		return fmt.Errorf("failed to store coin categories: %w", err)
 	}
+	syncRunFromContext(ctx).addWritten(len(categories))
+
+	// Categories no longer listed by the API have been removed
+	listed := make([]string, len(categories))
+	for i, c := range categories {
+		listed[i] = c.CoingeckoID
+	}
+	if _, err := markDelisted(domain.SyncKindCoinCategories, listed, len(stored), s.repository.MarkDelisted); err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to mark delisted coin categories")
+		return fmt.Errorf("failed to mark delisted coin categories: %w", err)
+	}
 
 	logger.GetLogger().WithField("count", len(categories)).Info("Successfully fetched and stored coin categories")
 	return nil
@@ -102,11 +128,15 @@ func (s *coinCategoryService) GetCoinCategoryByCoingeckoID(coingeckoID string) (
 
 // SyncCoinCategories synchronizes coin categories with the CoinGecko API
 // This method fetches fresh data and updates the database
-func (s *coinCategoryService) SyncCoinCategories() error {
+func (s *coinCategoryService) SyncCoinCategories(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindCoinCategories)
+	defer func() { run.finish(err) }()
+
 	logger.GetLogger().Info("Starting coin categories synchronization")
 
 	// Get current count from database
 	currentCategories, err := s.repository.GetAll()
+	countsKnown := err == nil
 	if err != nil {
 		logger.GetLogger().WithError(err).Warn("Failed to get current category count, proceeding with sync")
 	} else {
@@ -114,7 +144,7 @@ func (s *coinCategoryService) SyncCoinCategories() error {
 	}
 
 	// Fetch and store fresh data
-	if err := s.FetchAndStoreCoinCategories(); err != nil {
+	if err := s.FetchAndStoreCoinCategories(ctx); err != nil {
 		return fmt.Errorf("failed to sync coin categories: %w", err)
 	}
 
@@ -124,8 +154,120 @@ func (s *coinCategoryService) SyncCoinCategories() error {
 		logger.GetLogger().WithError(err).Warn("Failed to get updated category count")
 	} else {
 		logger.GetLogger().WithField("updated_count", len(updatedCategories)).Info("Coin categories after synchronization")
+		if countsKnown {
+			// Compare IDs rather than counts since delisted categories drop out of the updated list
+			known := make(map[string]bool, len(currentCategories))
+			for _, c := range currentCategories {
+				known[c.CoingeckoID] = true
+			}
+			inserted := 0
+			for _, c := range updatedCategories {
+				if !known[c.CoingeckoID] {
+					inserted++
+				}
+			}
+			run.addInserted(inserted)
+		}
 	}
 
 	logger.GetLogger().Info("Coin categories synchronization completed successfully")
 	return nil
 }
+
+// SyncCoinCategoriesMarket fetches /coins/categories, stores the market cap, 24h change, volume and top 3 coins of
+// every category on coin_categories and appends a snapshot of each to category_snapshots.
+// Categories are not delisted here; SyncCoinCategories owns the list.
+func (s *coinCategoryService) SyncCoinCategoriesMarket(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindCoinCategoriesMarket)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting coin categories market data synchronization")
+	capturedAt := time.Now().UTC().Truncate(time.Second)
+
+	current, err := s.repository.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get current coin categories: %w", err)
+	}
+
+	response, err := s.apiClient.GetCoinCategoriesMarket(ctx)
+	if err != nil {
+		return err
+	}
+
+	categories := make([]domain.CoinCategory, 0, len(response))
+	seen := make(map[string]bool, len(response))
+	for _, c := range response {
+		if c.ID == "" || seen[c.ID] {
+			run.addSkipped(1)
+			continue
+		}
+		seen[c.ID] = true
+		categories = append(categories, coinCategoryMarket(c))
+	}
+
+	if err := s.repository.UpsertMarketData(categories); err != nil {
+		return err
+	}
+	run.addWritten(len(categories))
+
+	updated, err := s.repository.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get updated coin categories: %w", err)
+	}
+	run.addInserted(len(updated) - len(current))
+
+	// Soft-deleted categories still get their market data updated, so they are snapshotted too
+	listed := make([]string, 0, len(categories))
+	for _, c := range categories {
+		listed = append(listed, c.CoingeckoID)
+	}
+	ids, err := s.repository.GetIDsByCoingeckoIDs(listed)
+	if err != nil {
+		return err
+	}
+
+	snapshots := make([]domain.CategorySnapshot, 0, len(categories))
+	for _, c := range categories {
+		id, ok := ids[c.CoingeckoID]
+		if !ok {
+			continue
+		}
+		snapshots = append(snapshots, domain.CategorySnapshot{
+			CategoryID:         id,
+			CapturedAt:         capturedAt,
+			MarketCap:          c.MarketCap,
+			MarketCapChange24h: c.MarketCapChange24h,
+			Volume24h:          c.Volume24h,
+		})
+	}
+	if err := s.snapshotRepo.CreateBatch(snapshots); err != nil {
+		return err
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"categories": len(categories),
+		"snapshots":  len(snapshots),
+	}).Info("Coin categories market data synchronization completed")
+	return nil
+}
+
+// coinCategoryMarket converts a /coins/categories entry into a category with its market figures
+func coinCategoryMarket(c CoinCategoryMarketResponse) domain.CoinCategory {
+	category := domain.CoinCategory{
+		CoingeckoID:        c.ID,
+		Name:               c.Name,
+		MarketCap:          c.MarketCap,
+		MarketCapChange24h: c.MarketCapChange24h,
+		Volume24h:          c.Volume24h,
+		Top3CoinIDs:        c.Top3CoinsID,
+		Top3Coins:          c.Top3Coins,
+	}
+	if content := strings.TrimSpace(c.Content); content != "" {
+		category.Content = &content
+	}
+	if t, err := time.Parse(time.RFC3339, c.UpdatedAt); err == nil {
+		t = t.UTC()
+		category.MarketDataUpdatedAt = &t
+	}
+	return category
+}
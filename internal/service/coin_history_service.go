@@ -0,0 +1,308 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultVsCurrency is the quote currency used when none is requested
+const DefaultVsCurrency = "usd"
+
+// BackfillOptions selects what BackfillHistory downloads
+type BackfillOptions struct {
+	From           time.Time
+	To             time.Time
+	Granularity    string
+	VsCurrency     string
+	MinTotalVolume float64
+	Workers        int // number of concurrent workers
+}
+
+// CoinHistoryService defines the interface for historical market chart operations
+type CoinHistoryService interface {
+	BackfillHistory(ctx context.Context, opts BackfillOptions) error
+}
+
+type coinHistoryService struct {
+	coinRepo        repository.CoinRepository
+	chartRepo       repository.CoinMarketChartRepository
+	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
+}
+
+// NewCoinHistoryService creates a new instance of CoinHistoryService
+func NewCoinHistoryService(
+	coinRepo repository.CoinRepository,
+	chartRepo repository.CoinMarketChartRepository,
+	client *CoinGeckoClient,
+	journal *SyncJournal,
+) CoinHistoryService {
+	return &coinHistoryService{
+		coinRepo:        coinRepo,
+		chartRepo:       chartRepo,
+		coingeckoClient: client,
+		journal:         journal,
+	}
+}
+
+// chartGranularity describes how a granularity maps onto /market_chart/range requests.
+// CoinGecko derives granularity from the requested range: up to 1 day is 5-minutely,
+// up to 90 days hourly and anything longer daily, so each chunk must stay within those bounds.
+type chartGranularity struct {
+	step     time.Duration // bucket size
+	maxChunk time.Duration // longest range fetched per request
+	minChunk time.Duration // shortest range that still yields this granularity
+}
+
+var chartGranularities = map[string]chartGranularity{
+	domain.ChartGranularityDaily:  {step: 24 * time.Hour, maxChunk: 365 * 24 * time.Hour, minChunk: 91 * 24 * time.Hour},
+	domain.ChartGranularityHourly: {step: time.Hour, maxChunk: 90 * 24 * time.Hour, minChunk: 2 * 24 * time.Hour},
+}
+
+// BackfillHistory downloads market charts for coins above the volume threshold and stores them bucket by bucket.
+// Chunks whose buckets are all stored already are skipped, so reruns only fetch the gaps.
+func (s *coinHistoryService) BackfillHistory(ctx context.Context, opts BackfillOptions) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindBackfillHistory)
+	defer func() { run.finish(err) }()
+
+	gran, ok := chartGranularities[opts.Granularity]
+	if !ok {
+		return fmt.Errorf("unsupported granularity %q (expected %s or %s)", opts.Granularity, domain.ChartGranularityDaily, domain.ChartGranularityHourly)
+	}
+	if opts.VsCurrency == "" {
+		opts.VsCurrency = DefaultVsCurrency
+	}
+
+	from := opts.From.UTC().Truncate(gran.step)
+	to := opts.To.UTC().Truncate(gran.step)
+	if !from.Before(to) {
+		return fmt.Errorf("invalid backfill range: from %s is not before to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"from":             from.Format(time.RFC3339),
+		"to":               to.Format(time.RFC3339),
+		"granularity":      opts.Granularity,
+		"vs_currency":      opts.VsCurrency,
+		"min_total_volume": opts.MinTotalVolume,
+	}).Info("Starting market chart history backfill")
+
+	coins, err := s.coinRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to load coins: %w", err)
+	}
+
+	filtered := filterByMinTotalVolume(coins, opts.MinTotalVolume)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"eligible": len(filtered),
+		"total":    len(coins),
+	}).Info("Coins eligible for history backfill by volume filter")
+
+	type coinResult struct {
+		fetched, skipped, stored int
+		err                      error
+	}
+	var fetched, skipped, stored int
+	runBounded(len(filtered), opts.Workers,
+		func(int) bool { return ctx.Err() == nil },
+		func(i int) coinResult {
+			f, sk, st, err := s.backfillCoin(ctx, filtered[i], opts, gran, from, to)
+			return coinResult{fetched: f, skipped: sk, stored: st, err: err}
+		},
+		func(i int, r coinResult) {
+			fetched += r.fetched
+			skipped += r.skipped
+			stored += r.stored
+			if r.err != nil && ctx.Err() == nil {
+				logger.GetLogger().WithError(r.err).WithField("coin_id", filtered[i].CoingeckoID).Warn("Failed to backfill coin history; skipping")
+				run.addSkipped(1)
+			}
+		},
+	)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("history backfill interrupted: %w", err)
+	}
+
+	stats := s.coingeckoClient.RateLimitStats()
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"coins":          len(filtered),
+		"chunks_fetched": fetched,
+		"chunks_skipped": skipped,
+		"points_stored":  stored,
+		"api_calls":      stats.Calls,
+		"throttled":      stats.Throttled,
+		"rate_waited":    stats.Waited.String(),
+	}).Info("Market chart history backfill completed")
+	return nil
+}
+
+// backfillCoin fills the missing buckets of one coin and reports chunks fetched, chunks skipped and points stored
+func (s *coinHistoryService) backfillCoin(ctx context.Context, coin domain.Coin, opts BackfillOptions, gran chartGranularity, from, to time.Time) (int, int, int, error) {
+	// Buckets before the known start of the coin's history came back empty before and would only be requested again
+	availableFrom, err := s.chartRepo.GetAvailableFrom(coin.ID, opts.VsCurrency, opts.Granularity)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if availableFrom != nil && availableFrom.After(from) {
+		from = *availableFrom
+	}
+	if !from.Before(to) {
+		return 0, 0, 0, nil
+	}
+
+	existing, err := s.chartRepo.GetTimestamps(coin.ID, opts.VsCurrency, opts.Granularity, from, to)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	have := make(map[time.Time]bool, len(existing))
+	for _, ts := range existing {
+		have[ts.UTC()] = true
+	}
+
+	run := syncRunFromContext(ctx)
+	var fetched, skipped, stored int
+	for chunkFrom := from; chunkFrom.Before(to); {
+		chunkTo := chunkFrom.Add(gran.maxChunk)
+		if chunkTo.After(to) {
+			chunkTo = to
+		}
+
+		if chunkComplete(have, chunkFrom, chunkTo, gran.step) {
+			skipped++
+			chunkFrom = chunkTo
+			continue
+		}
+
+		// Short chunks are widened backwards so CoinGecko still answers with the wanted granularity
+		requestFrom := chunkFrom
+		if chunkTo.Sub(requestFrom) < gran.minChunk {
+			requestFrom = chunkTo.Add(-gran.minChunk)
+		}
+
+		chart, err := s.coingeckoClient.GetCoinMarketChartRange(ctx, coin.CoingeckoID, opts.VsCurrency, requestFrom, chunkTo)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fetched, skipped, stored, err
+			}
+			// Older ranges may be outside the plan's history window; later chunks can still succeed
+			logger.GetLogger().WithError(err).WithFields(map[string]interface{}{
+				"coin_id": coin.CoingeckoID,
+				"from":    chunkFrom.Format(time.RFC3339),
+				"to":      chunkTo.Format(time.RFC3339),
+			}).Warn("Failed to fetch market chart chunk; skipping")
+			chunkFrom = chunkTo
+			continue
+		}
+		fetched++
+
+		// CoinGecko returns every sample it has in the range, so nothing before the first one exists, unless
+		// older buckets are stored, by this run or an earlier one, and the chunk merely starts in a gap
+		if start := chartStart(chart, gran.step, chunkTo); start.After(requestFrom) {
+			older, err := s.chartRepo.HasPointsBefore(coin.ID, opts.VsCurrency, opts.Granularity, start)
+			if err != nil {
+				return fetched, skipped, stored, err
+			}
+			if !older {
+				if err := s.chartRepo.SetAvailableFrom(coin.ID, opts.VsCurrency, opts.Granularity, start); err != nil {
+					return fetched, skipped, stored, err
+				}
+			}
+		}
+
+		points := chartPoints(coin.ID, opts.VsCurrency, opts.Granularity, gran.step, chart, chunkFrom, chunkTo, have)
+		if err := s.chartRepo.CreateBatch(points); err != nil {
+			return fetched, skipped, stored, err
+		}
+		for _, p := range points {
+			have[p.Timestamp] = true
+		}
+		stored += len(points)
+		run.addWritten(len(points))
+		run.addInserted(len(points))
+
+		chunkFrom = chunkTo
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"coin_id":        coin.CoingeckoID,
+		"chunks_fetched": fetched,
+		"chunks_skipped": skipped,
+		"points_stored":  stored,
+	}).Info("Backfilled coin market chart history")
+	return fetched, skipped, stored, nil
+}
+
+// chunkComplete reports whether every bucket in [from, to) is already stored
+func chunkComplete(have map[time.Time]bool, from, to time.Time, step time.Duration) bool {
+	for ts := from; ts.Before(to); ts = ts.Add(step) {
+		if !have[ts] {
+			return false
+		}
+	}
+	return true
+}
+
+// chartStart returns the bucket of the earliest sample in the chart, or end when the chart is empty
+func chartStart(chart *MarketChartResponse, step time.Duration, end time.Time) time.Time {
+	start := end
+	for _, series := range [][][2]*float64{chart.Prices, chart.MarketCaps, chart.TotalVolumes} {
+		for _, sample := range series {
+			if sample[0] == nil || sample[1] == nil {
+				continue
+			}
+			if bucket := time.UnixMilli(int64(*sample[0])).UTC().Truncate(step); bucket.Before(start) {
+				start = bucket
+			}
+		}
+	}
+	return start
+}
+
+// chartPoints merges the three chart series into one point per bucket within [from, to),
+// keeping the earliest sample of each bucket and leaving out buckets that are already stored
+func chartPoints(coinID uint, vsCurrency, granularity string, step time.Duration, chart *MarketChartResponse, from, to time.Time, have map[time.Time]bool) []domain.CoinMarketChartPoint {
+	byBucket := make(map[time.Time]*domain.CoinMarketChartPoint)
+	var order []time.Time
+
+	merge := func(series [][2]*float64, field func(p *domain.CoinMarketChartPoint) **float64) {
+		for _, sample := range series {
+			if sample[0] == nil || sample[1] == nil {
+				continue
+			}
+			bucket := time.UnixMilli(int64(*sample[0])).UTC().Truncate(step)
+			if bucket.Before(from) || !bucket.Before(to) || have[bucket] {
+				continue
+			}
+			p, ok := byBucket[bucket]
+			if !ok {
+				p = &domain.CoinMarketChartPoint{
+					CoinID:      coinID,
+					VsCurrency:  vsCurrency,
+					Granularity: granularity,
+					Timestamp:   bucket,
+				}
+				byBucket[bucket] = p
+				order = append(order, bucket)
+			}
+			if dst := field(p); *dst == nil {
+				value := *sample[1]
+				*dst = &value
+			}
+		}
+	}
+
+	merge(chart.Prices, func(p *domain.CoinMarketChartPoint) **float64 { return &p.Price })
+	merge(chart.MarketCaps, func(p *domain.CoinMarketChartPoint) **float64 { return &p.MarketCap })
+	merge(chart.TotalVolumes, func(p *domain.CoinMarketChartPoint) **float64 { return &p.TotalVolume })
+
+	points := make([]domain.CoinMarketChartPoint, 0, len(order))
+	for _, bucket := range order {
+		points = append(points, *byBucket[bucket])
+	}
+	return points
+}
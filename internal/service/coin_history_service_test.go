@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func chartSample(t time.Time, v float64) [2]*float64 {
+	ms := float64(t.UnixMilli())
+	return [2]*float64{&ms, &v}
+}
+
+func TestChunkComplete(t *testing.T) {
+	day := 24 * time.Hour
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * day)
+
+	tests := []struct {
+		name string
+		have []time.Time
+		want bool
+	}{
+		{name: "nothing stored", want: false},
+		{name: "every bucket stored", have: []time.Time{from, from.Add(day), from.Add(2 * day)}, want: true},
+		{name: "middle bucket missing", have: []time.Time{from, from.Add(2 * day)}, want: false},
+		{name: "bucket at to does not count", have: []time.Time{from.Add(day), from.Add(2 * day), to}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have := make(map[time.Time]bool)
+			for _, ts := range tt.have {
+				have[ts] = true
+			}
+			if got := chunkComplete(have, from, to, day); got != tt.want {
+				t.Errorf("chunkComplete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !chunkComplete(nil, from, from, day) {
+		t.Error("chunkComplete() of an empty range = false, want true")
+	}
+}
+
+func TestChartPoints(t *testing.T) {
+	day := 24 * time.Hour
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * day)
+
+	chart := &MarketChartResponse{
+		Prices: [][2]*float64{
+			chartSample(from.Add(-time.Hour), 1),       // before the range
+			chartSample(from.Add(time.Minute), 10),     // first sample of day 1
+			chartSample(from.Add(time.Hour), 11),       // later sample of day 1
+			chartSample(from.Add(day+time.Hour), 20),   // day 2 is already stored
+			chartSample(from.Add(2*day+time.Hour), 30), // day 3
+			chartSample(to, 40),                        // at the exclusive end
+			{nil, nil},
+		},
+		MarketCaps: [][2]*float64{
+			chartSample(from.Add(time.Minute), 100),
+			chartSample(from.Add(2*day), 300),
+		},
+		TotalVolumes: [][2]*float64{
+			chartSample(from.Add(2*day+time.Minute), 3000),
+		},
+	}
+	have := map[time.Time]bool{from.Add(day): true}
+
+	points := chartPoints(7, "usd", "daily", day, chart, from, to, have)
+	if len(points) != 2 {
+		t.Fatalf("chartPoints() returned %d points, want 2: %+v", len(points), points)
+	}
+
+	first := points[0]
+	if !first.Timestamp.Equal(from) || first.CoinID != 7 || first.VsCurrency != "usd" || first.Granularity != "daily" {
+		t.Errorf("first point = %+v, want coin 7 usd daily at %s", first, from)
+	}
+	if first.Price == nil || *first.Price != 10 {
+		t.Errorf("first point price = %v, want the earliest sample 10", first.Price)
+	}
+	if first.MarketCap == nil || *first.MarketCap != 100 {
+		t.Errorf("first point market cap = %v, want 100", first.MarketCap)
+	}
+	if first.TotalVolume != nil {
+		t.Errorf("first point total volume = %v, want nil", *first.TotalVolume)
+	}
+
+	last := points[1]
+	if !last.Timestamp.Equal(from.Add(2 * day)) {
+		t.Errorf("last point timestamp = %s, want %s", last.Timestamp, from.Add(2*day))
+	}
+	if last.Price == nil || *last.Price != 30 || last.MarketCap == nil || *last.MarketCap != 300 ||
+		last.TotalVolume == nil || *last.TotalVolume != 3000 {
+		t.Errorf("last point = %+v, want price 30, market cap 300 and total volume 3000", last)
+	}
+}
+
+func TestChartStart(t *testing.T) {
+	day := 24 * time.Hour
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	listed := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	empty := &MarketChartResponse{}
+	if got := chartStart(empty, day, end); !got.Equal(end) {
+		t.Errorf("chartStart() of an empty chart = %s, want %s", got, end)
+	}
+
+	chart := &MarketChartResponse{
+		Prices:     [][2]*float64{chartSample(listed.Add(day+time.Hour), 1)},
+		MarketCaps: [][2]*float64{chartSample(listed.Add(time.Hour), 1), {nil, nil}},
+	}
+	if got := chartStart(chart, day, end); !got.Equal(listed) {
+		t.Errorf("chartStart() = %s, want %s", got, listed)
+	}
+}
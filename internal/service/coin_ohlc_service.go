@@ -0,0 +1,234 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+// OHLCOptions selects which candles SyncOHLC keeps up to date
+type OHLCOptions struct {
+	Interval       string
+	VsCurrency     string
+	MinTotalVolume float64
+	Workers        int // number of concurrent workers
+}
+
+// CoinOHLCService defines the interface for OHLC candle operations
+type CoinOHLCService interface {
+	SyncOHLC(ctx context.Context, opts OHLCOptions) error
+}
+
+type coinOHLCService struct {
+	coinRepo        repository.CoinRepository
+	ohlcRepo        repository.CoinOHLCRepository
+	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
+}
+
+// NewCoinOHLCService creates a new instance of CoinOHLCService
+func NewCoinOHLCService(
+	coinRepo repository.CoinRepository,
+	ohlcRepo repository.CoinOHLCRepository,
+	client *CoinGeckoClient,
+	journal *SyncJournal,
+) CoinOHLCService {
+	return &coinOHLCService{
+		coinRepo:        coinRepo,
+		ohlcRepo:        ohlcRepo,
+		coingeckoClient: client,
+		journal:         journal,
+	}
+}
+
+// ohlcInterval describes how candles of one interval are fetched.
+// Intervals with days values come from /ohlc, where the candle size is implied by the days requested;
+// the others come from /ohlc/range in chunks of at most maxChunk.
+type ohlcInterval struct {
+	step     time.Duration
+	days     []int         // /ohlc days values yielding this candle size, ascending; 0 means "max"
+	maxChunk time.Duration // /ohlc/range only
+}
+
+var ohlcIntervals = map[string]ohlcInterval{
+	domain.OHLCInterval30m:    {step: 30 * time.Minute, days: []int{1}},
+	domain.OHLCInterval4h:     {step: 4 * time.Hour, days: []int{7, 14, 30}},
+	domain.OHLCInterval4d:     {step: 4 * 24 * time.Hour, days: []int{90, 180, 365, 0}},
+	domain.OHLCIntervalHourly: {step: time.Hour, maxChunk: 31 * 24 * time.Hour},
+	domain.OHLCIntervalDaily:  {step: 24 * time.Hour, maxChunk: 180 * 24 * time.Hour},
+}
+
+// SyncOHLC fetches candles for coins above the volume threshold, starting from the newest stored candle of each coin.
+// The newest candle is fetched again so a candle that was still forming at the previous sync gets its final prices.
+func (s *coinOHLCService) SyncOHLC(ctx context.Context, opts OHLCOptions) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindOHLC)
+	defer func() { run.finish(err) }()
+
+	interval, ok := ohlcIntervals[opts.Interval]
+	if !ok {
+		return fmt.Errorf("unsupported OHLC interval %q", opts.Interval)
+	}
+	if opts.VsCurrency == "" {
+		opts.VsCurrency = DefaultVsCurrency
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"interval":         opts.Interval,
+		"vs_currency":      opts.VsCurrency,
+		"min_total_volume": opts.MinTotalVolume,
+	}).Info("Starting OHLC synchronization")
+
+	coins, err := s.coinRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to load coins: %w", err)
+	}
+
+	filtered := filterByMinTotalVolume(coins, opts.MinTotalVolume)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"eligible": len(filtered),
+		"total":    len(coins),
+	}).Info("Coins eligible for OHLC sync by volume filter")
+
+	type coinResult struct {
+		stored int
+		err    error
+	}
+	stored := 0
+	runBounded(len(filtered), opts.Workers,
+		func(int) bool { return ctx.Err() == nil },
+		func(i int) coinResult {
+			n, err := s.syncCoinOHLC(ctx, filtered[i], opts, interval)
+			return coinResult{stored: n, err: err}
+		},
+		func(i int, r coinResult) {
+			stored += r.stored
+			if r.err != nil && ctx.Err() == nil {
+				logger.GetLogger().WithError(r.err).WithField("coin_id", filtered[i].CoingeckoID).Warn("Failed to sync coin OHLC; skipping")
+				run.addSkipped(1)
+			}
+		},
+	)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("OHLC synchronization interrupted: %w", err)
+	}
+
+	stats := s.coingeckoClient.RateLimitStats()
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"coins":          len(filtered),
+		"candles_stored": stored,
+		"api_calls":      stats.Calls,
+		"throttled":      stats.Throttled,
+		"rate_waited":    stats.Waited.String(),
+	}).Info("OHLC synchronization completed")
+	return nil
+}
+
+// syncCoinOHLC brings one coin's candles up to date and returns how many candles were written
+func (s *coinOHLCService) syncCoinOHLC(ctx context.Context, coin domain.Coin, opts OHLCOptions, interval ohlcInterval) (int, error) {
+	since, err := s.ohlcRepo.GetLatestOpenTime(coin.ID, opts.VsCurrency, opts.Interval)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+	// The newest candle is still forming, so there is nothing to finalize or add yet
+	if since != nil && since.Add(interval.step).After(now) {
+		logger.GetLogger().WithField("coin_id", coin.CoingeckoID).Debug("Coin OHLC up to date; skipping")
+		return 0, nil
+	}
+
+	var raw OHLCResponse
+
+	if interval.days != nil {
+		days := ohlcDays(interval, since, now)
+		raw, err = s.coingeckoClient.GetCoinOHLC(ctx, coin.CoingeckoID, opts.VsCurrency, days)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		// Without stored candles start one chunk back; older history is left to a dedicated backfill
+		from := now.Add(-interval.maxChunk)
+		if since != nil {
+			from = *since
+		}
+		for chunkFrom := from; chunkFrom.Before(now); chunkFrom = chunkFrom.Add(interval.maxChunk) {
+			chunkTo := chunkFrom.Add(interval.maxChunk)
+			if chunkTo.After(now) {
+				chunkTo = now
+			}
+			chunk, err := s.coingeckoClient.GetCoinOHLCRange(ctx, coin.CoingeckoID, opts.VsCurrency, chunkFrom, chunkTo, opts.Interval)
+			if err != nil {
+				return 0, err
+			}
+			raw = append(raw, chunk...)
+		}
+	}
+
+	candles := ohlcCandles(coin.ID, opts.VsCurrency, opts.Interval, interval.step, raw, since)
+	if err := s.ohlcRepo.UpsertBatch(candles); err != nil {
+		return 0, err
+	}
+
+	// Every candle except a re-fetched newest one is new
+	run := syncRunFromContext(ctx)
+	run.addWritten(len(candles))
+	inserted := len(candles)
+	if since != nil && len(candles) > 0 && candles[0].OpenTime.Equal(*since) {
+		inserted--
+	}
+	run.addInserted(inserted)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"coin_id": coin.CoingeckoID,
+		"count":   len(candles),
+	}).Debug("Synced coin OHLC")
+	return len(candles), nil
+}
+
+// ohlcDays picks the smallest /ohlc days value that reaches back to since while keeping the candle size.
+// A gap longer than the largest value cannot be closed through /ohlc; the largest value is used and the rest stays missing.
+func ohlcDays(interval ohlcInterval, since *time.Time, now time.Time) string {
+	pick := interval.days[len(interval.days)-1]
+	if since != nil {
+		gap := now.Sub(*since) + interval.step
+		for _, d := range interval.days {
+			if d == 0 || time.Duration(d)*24*time.Hour >= gap {
+				pick = d
+				break
+			}
+		}
+	}
+	if pick == 0 {
+		return "max"
+	}
+	return fmt.Sprintf("%d", pick)
+}
+
+// ohlcCandles converts raw candles into rows, dropping candles older than since.
+// CoinGecko timestamps a candle with its close time, so the open time is one step earlier.
+func ohlcCandles(coinID uint, vsCurrency, interval string, step time.Duration, raw OHLCResponse, since *time.Time) []domain.CoinOHLC {
+	seen := make(map[time.Time]bool, len(raw))
+	candles := make([]domain.CoinOHLC, 0, len(raw))
+	for _, r := range raw {
+		openTime := time.UnixMilli(int64(r[0])).UTC().Add(-step)
+		if (since != nil && openTime.Before(*since)) || seen[openTime] {
+			continue
+		}
+		seen[openTime] = true
+		candles = append(candles, domain.CoinOHLC{
+			CoinID:     coinID,
+			VsCurrency: vsCurrency,
+			Interval:   interval,
+			OpenTime:   openTime,
+			Open:       r[1],
+			High:       r[2],
+			Low:        r[3],
+			Close:      r[4],
+		})
+	}
+	return candles
+}
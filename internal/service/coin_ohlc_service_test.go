@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"cgoffline/internal/domain"
+)
+
+func TestOHLCDays(t *testing.T) {
+	now := time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		interval string
+		since    *time.Time
+		want     string
+	}{
+		{name: "30m without candles", interval: domain.OHLCInterval30m, want: "1"},
+		{name: "30m recent candle", interval: domain.OHLCInterval30m, since: ago(time.Hour), want: "1"},
+		{name: "30m gap beyond one day", interval: domain.OHLCInterval30m, since: ago(3 * day), want: "1"},
+		{name: "4h without candles", interval: domain.OHLCInterval4h, want: "30"},
+		{name: "4h recent candle", interval: domain.OHLCInterval4h, since: ago(8 * time.Hour), want: "7"},
+		{name: "4h gap plus one candle fits 7 days", interval: domain.OHLCInterval4h, since: ago(7*day - 4*time.Hour), want: "7"},
+		{name: "4h gap plus one candle exceeds 7 days", interval: domain.OHLCInterval4h, since: ago(7 * day), want: "14"},
+		{name: "4h gap beyond 30 days", interval: domain.OHLCInterval4h, since: ago(60 * day), want: "30"},
+		{name: "4d without candles", interval: domain.OHLCInterval4d, want: "max"},
+		{name: "4d recent candle", interval: domain.OHLCInterval4d, since: ago(4 * day), want: "90"},
+		{name: "4d gap beyond a year", interval: domain.OHLCInterval4d, since: ago(400 * day), want: "max"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ohlcDays(ohlcIntervals[tt.interval], tt.since, now); got != tt.want {
+				t.Errorf("ohlcDays() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOHLCCandles(t *testing.T) {
+	step := 4 * time.Hour
+	open := time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)
+	closeMs := func(openTime time.Time) float64 { return float64(openTime.Add(step).UnixMilli()) }
+
+	raw := OHLCResponse{
+		{closeMs(open.Add(-step)), 1, 2, 0.5, 1.5},  // older than since
+		{closeMs(open), 10, 12, 9, 11},              // the re-fetched newest candle
+		{closeMs(open), 99, 99, 99, 99},             // duplicate of the same candle
+		{closeMs(open.Add(step)), 11, 13, 10, 12.5}, // new candle
+	}
+
+	t.Run("since drops older candles", func(t *testing.T) {
+		candles := ohlcCandles(3, "usd", domain.OHLCInterval4h, step, raw, &open)
+		if len(candles) != 2 {
+			t.Fatalf("ohlcCandles() returned %d candles, want 2: %+v", len(candles), candles)
+		}
+		first := candles[0]
+		if !first.OpenTime.Equal(open) {
+			t.Errorf("first open time = %s, want the close time minus one step %s", first.OpenTime, open)
+		}
+		if first.CoinID != 3 || first.VsCurrency != "usd" || first.Interval != domain.OHLCInterval4h {
+			t.Errorf("first candle = %+v, want coin 3 usd 4h", first)
+		}
+		if first.Open != 10 || first.High != 12 || first.Low != 9 || first.Close != 11 {
+			t.Errorf("first candle prices = %+v, want the first of the duplicates", first)
+		}
+		if !candles[1].OpenTime.Equal(open.Add(step)) || candles[1].Close != 12.5 {
+			t.Errorf("second candle = %+v, want the new candle", candles[1])
+		}
+	})
+
+	t.Run("without since every candle is kept", func(t *testing.T) {
+		if candles := ohlcCandles(3, "usd", domain.OHLCInterval4h, step, raw, nil); len(candles) != 3 {
+			t.Errorf("ohlcCandles() returned %d candles, want 3", len(candles))
+		}
+	})
+}
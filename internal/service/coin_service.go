@@ -7,14 +7,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
 // CoinService defines the interface for coin operations
 type CoinService interface {
-	SyncCoins() error
-	SyncCoinMarketData(coinID string) error
-	SyncCoinsData(minTotalVolume float64) error
+	SyncCoins(ctx context.Context, opts CoinsOptions) error
+	SyncCoinList(ctx context.Context) error
+	SyncCoinMarketData(ctx context.Context, coinID string) error
+	SyncCoinsData(ctx context.Context, opts CoinsDataOptions) error
+}
+
+// CoinsOptions controls SyncCoins
+type CoinsOptions struct {
+	// VsCurrencies are stored in coin_quotes; USD figures are always kept on the coins themselves
+	VsCurrencies []string
+}
+
+// CoinsDataOptions controls SyncCoinsData
+type CoinsDataOptions struct {
+	MinTotalVolume float64
+	Freshness      time.Duration // coins whose details were stored more recently are skipped; 0 refetches every coin
+	MaxDuration    time.Duration // the sync stops cleanly before running longer than this; 0 means no limit
+	Workers        int           // number of concurrent workers
 }
 
 type coinService struct {
@@ -24,6 +41,12 @@ type coinService struct {
 	coingeckoClient    *CoinGeckoClient
 	coinDetailRepo     repository.CoinDetailRepository
 	coinTickerRepo     repository.CoinTickerRepository
+	snapshotRepo       repository.CoinMarketSnapshotRepository
+	quoteRepo          repository.CoinQuoteRepository
+	membershipRepo     repository.CoinCategoryMembershipRepository
+	contractRepo       repository.CoinContractRepository
+	checkpointRepo     repository.SyncCheckpointRepository
+	journal            *SyncJournal
 }
 
 // NewCoinService creates a new instance of CoinService
@@ -33,7 +56,13 @@ func NewCoinService(
 	exchangeRepo repository.ExchangeRepository,
 	coinDetailRepo repository.CoinDetailRepository,
 	coinTickerRepo repository.CoinTickerRepository,
+	snapshotRepo repository.CoinMarketSnapshotRepository,
+	quoteRepo repository.CoinQuoteRepository,
+	membershipRepo repository.CoinCategoryMembershipRepository,
+	contractRepo repository.CoinContractRepository,
+	checkpointRepo repository.SyncCheckpointRepository,
 	client *CoinGeckoClient,
+	journal *SyncJournal,
 ) CoinService {
 	return &coinService{
 		coinRepo:           coinRepo,
@@ -41,16 +70,23 @@ func NewCoinService(
 		exchangeRepo:       exchangeRepo,
 		coinDetailRepo:     coinDetailRepo,
 		coinTickerRepo:     coinTickerRepo,
+		snapshotRepo:       snapshotRepo,
+		quoteRepo:          quoteRepo,
+		membershipRepo:     membershipRepo,
+		contractRepo:       contractRepo,
+		checkpointRepo:     checkpointRepo,
 		coingeckoClient:    client,
+		journal:            journal,
 	}
 }
 
-// SyncCoins fetches coins from CoinGecko API and stores them in the database
-func (s *coinService) SyncCoins() error {
-	logger.GetLogger().Info("Starting coins synchronization")
+// SyncCoins fetches coins from CoinGecko API and stores them in the database.
+// Coins are listed in USD; every other currency in opts.VsCurrencies is fetched afterwards and only updates coin_quotes.
+func (s *coinService) SyncCoins(ctx context.Context, opts CoinsOptions) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindCoins)
+	defer func() { run.finish(err) }()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second) // 5 minutes timeout
-	defer cancel()
+	logger.GetLogger().Info("Starting coins synchronization")
 
 	// Get current coins in DB for logging purposes
 	currentCoins, err := s.coinRepo.GetAll()
@@ -60,6 +96,14 @@ func (s *coinService) SyncCoins() error {
 	}
 	logger.GetLogger().WithField("current_count", len(currentCoins)).Info("Current coins in database")
 
+	// Every snapshot appended by this run shares the same capture time
+	capturedAt := time.Now().UTC().Truncate(time.Second)
+
+	quoteUSD := false
+	for _, vs := range opts.VsCurrencies {
+		quoteUSD = quoteUSD || vs == DefaultVsCurrency
+	}
+
 	// Fetch coins in batches (CoinGecko API returns max 250 per page)
 	page := 1
 	perPage := 250
@@ -72,7 +116,7 @@ func (s *coinService) SyncCoins() error {
 		}).Info("Fetching coins page")
 
 		// Fetch coins from CoinGecko API
-		apiCoins, err := s.coingeckoClient.GetCoins(ctx, page, perPage)
+		apiCoins, err := s.coingeckoClient.GetCoins(ctx, DefaultVsCurrency, page, perPage)
 		if err != nil {
 			logger.GetLogger().WithError(err).WithField("page", page).Error("Failed to fetch coins from API")
 			return fmt.Errorf("failed to fetch coins page %d: %w", page, err)
@@ -90,7 +134,21 @@ func (s *coinService) SyncCoins() error {
 			return fmt.Errorf("failed to store coins page %d: %w", page, err)
 		}
 
+		// Append market snapshots so history survives the in-place update above
+		if err := s.storeMarketSnapshots(apiCoins, capturedAt); err != nil {
+			logger.GetLogger().WithError(err).WithField("page", page).Error("Failed to store coin market snapshots")
+			return fmt.Errorf("failed to store coin market snapshots page %d: %w", page, err)
+		}
+
+		if quoteUSD {
+			if err := s.storeQuotes(ctx, apiCoins, DefaultVsCurrency); err != nil {
+				logger.GetLogger().WithError(err).WithField("page", page).Error("Failed to store coin quotes")
+				return fmt.Errorf("failed to store coin quotes page %d: %w", page, err)
+			}
+		}
+
 		totalFetched += len(apiCoins)
+		run.addWritten(len(apiCoins))
 		logger.GetLogger().WithFields(map[string]interface{}{
 			"page":          page,
 			"count":         len(apiCoins),
@@ -104,13 +162,12 @@ func (s *coinService) SyncCoins() error {
 		}
 
 		page++
-
-		// Add a small delay to respect rate limits
-		time.Sleep(1 * time.Second)
 	}
 
 	logger.GetLogger().WithField("total_fetched", totalFetched).Info("Successfully fetched and stored all coins")
 
+	// Coins missing here may merely lack market data; delisting is left to SyncCoinList, which sees every coin
+
 	// Verify count after sync
 	updatedCoins, err := s.coinRepo.GetAll()
 	if err != nil {
@@ -118,17 +175,215 @@ func (s *coinService) SyncCoins() error {
 		return fmt.Errorf("failed to get updated coins: %w", err)
 	}
 	logger.GetLogger().WithField("updated_count", len(updatedCoins)).Info("Coins after synchronization")
+	run.addInserted(len(updatedCoins) - len(currentCoins))
+
+	for _, vs := range opts.VsCurrencies {
+		if vs == DefaultVsCurrency {
+			continue
+		}
+		if err := s.syncQuotes(ctx, vs, perPage); err != nil {
+			return err
+		}
+	}
 
 	logger.GetLogger().Info("Coins synchronization completed successfully")
 	return nil
 }
 
-// SyncCoinMarketData fetches market data for a specific coin and stores it in the database
-func (s *coinService) SyncCoinMarketData(coinID string) error {
-	logger.GetLogger().WithField("coin_id", coinID).Info("Starting coin market data synchronization")
+// SyncCoinList makes sure every coin CoinGecko lists, active or inactive, exists in coins, including coins
+// /coins/markets leaves out for lack of market data. Only identity and the active flag are written; the contract
+// addresses of the list are merged into coin_contracts. Coins in neither list are marked delisted.
+func (s *coinService) SyncCoinList(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindCoinList)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting coin list synchronization")
+
+	currentCoins, err := s.coinRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get current coins: %w", err)
+	}
+
+	var entries []CoinListResponse
+	coins := make([]domain.Coin, 0)
+	seen := make(map[string]bool)
+	inactive := 0
+	for _, status := range []string{CoinStatusActive, CoinStatusInactive} {
+		list, err := s.coingeckoClient.GetCoinList(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, e := range list {
+			// A coin listed under both statuses counts as active
+			if e.ID == "" || seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			if status == CoinStatusInactive {
+				inactive++
+			}
+			entries = append(entries, e)
+			coins = append(coins, domain.Coin{
+				CoingeckoID: e.ID,
+				Symbol:      e.Symbol,
+				Name:        e.Name,
+				Active:      status == CoinStatusActive,
+			})
+		}
+	}
+
+	if err := s.coinRepo.UpsertListed(coins); err != nil {
+		return err
+	}
+	run.addWritten(len(coins))
+
+	listed := make([]string, len(coins))
+	for i, c := range coins {
+		listed[i] = c.CoingeckoID
+	}
+	delisted, err := markDelisted(domain.SyncKindCoinList, listed, len(currentCoins), s.coinRepo.MarkDelisted)
+	if err != nil {
+		return fmt.Errorf("failed to mark delisted coins: %w", err)
+	}
+
+	updatedCoins, err := s.coinRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get updated coins: %w", err)
+	}
+	run.addInserted(len(updatedCoins) - len(currentCoins) + int(delisted))
+
+	contracts := listedContracts(entries, updatedCoins)
+	if err := s.contractRepo.UpsertAddresses(contracts); err != nil {
+		return err
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"coins":     len(coins),
+		"inactive":  inactive,
+		"delisted":  delisted,
+		"contracts": len(contracts),
+	}).Info("Coin list synchronization completed")
+	return nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
+// listedContracts turns the platforms of coin list entries into contracts of the stored coins
+func listedContracts(entries []CoinListResponse, stored []domain.Coin) []domain.CoinContract {
+	ids := make(map[string]uint, len(stored))
+	for _, c := range stored {
+		ids[c.CoingeckoID] = c.ID
+	}
+
+	var contracts []domain.CoinContract
+	for _, e := range entries {
+		coinID, ok := ids[e.ID]
+		if !ok {
+			continue
+		}
+		for platformID, address := range e.Platforms {
+			if platformID == "" || address == nil || strings.TrimSpace(*address) == "" {
+				continue
+			}
+			contracts = append(contracts, domain.CoinContract{CoinID: coinID, AssetPlatformID: platformID, ContractAddress: *address})
+		}
+	}
+	return contracts
+}
+
+// storeMarketSnapshots appends a snapshot for each fetched coin at the given capture time
+func (s *coinService) storeMarketSnapshots(coins []domain.Coin, capturedAt time.Time) error {
+	coingeckoIDs := make([]string, 0, len(coins))
+	for _, c := range coins {
+		coingeckoIDs = append(coingeckoIDs, c.CoingeckoID)
+	}
+
+	ids, err := s.coinRepo.GetIDsByCoingeckoIDs(coingeckoIDs)
+	if err != nil {
+		return err
+	}
+
+	snapshots := make([]domain.CoinMarketSnapshot, 0, len(coins))
+	for _, c := range coins {
+		coinID, ok := ids[c.CoingeckoID]
+		if !ok {
+			continue
+		}
+		snapshots = append(snapshots, domain.CoinMarketSnapshot{
+			CoinID:                coinID,
+			CapturedAt:            capturedAt,
+			Price:                 c.CurrentPrice,
+			MarketCap:             c.MarketCap,
+			TotalVolume:           c.TotalVolume,
+			FullyDilutedValuation: c.FullyDilutedValuation,
+			CirculatingSupply:     c.CirculatingSupply,
+			TotalSupply:           c.TotalSupply,
+			MaxSupply:             c.MaxSupply,
+			SourceUpdatedAt:       c.LastUpdated,
+		})
+	}
+
+	return s.snapshotRepo.CreateBatch(snapshots)
+}
+
+// syncQuotes pages through /coins/markets in one currency and stores the figures in coin_quotes.
+// Coins missing from the database, i.e. listed after the USD pass, are left for the next sync.
+func (s *coinService) syncQuotes(ctx context.Context, vsCurrency string, perPage int) error {
+	logger.GetLogger().WithField("vs_currency", vsCurrency).Info("Fetching coin quotes")
+
+	total := 0
+	for page := 1; ; page++ {
+		apiCoins, err := s.coingeckoClient.GetCoins(ctx, vsCurrency, page, perPage)
+		if err != nil {
+			logger.GetLogger().WithError(err).WithFields(map[string]interface{}{"vs_currency": vsCurrency, "page": page}).Error("Failed to fetch coin quotes from API")
+			return fmt.Errorf("failed to fetch %s coins page %d: %w", vsCurrency, page, err)
+		}
+		if err := s.storeQuotes(ctx, apiCoins, vsCurrency); err != nil {
+			logger.GetLogger().WithError(err).WithFields(map[string]interface{}{"vs_currency": vsCurrency, "page": page}).Error("Failed to store coin quotes")
+			return fmt.Errorf("failed to store %s coin quotes page %d: %w", vsCurrency, page, err)
+		}
+		total += len(apiCoins)
+		if len(apiCoins) < perPage {
+			break
+		}
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"vs_currency": vsCurrency,
+		"count":       total,
+	}).Info("Successfully fetched and stored coin quotes")
+	return nil
+}
+
+// storeQuotes upserts the quotes of coins fetched in vsCurrency
+func (s *coinService) storeQuotes(ctx context.Context, coins []domain.Coin, vsCurrency string) error {
+	coingeckoIDs := make([]string, 0, len(coins))
+	for _, c := range coins {
+		coingeckoIDs = append(coingeckoIDs, c.CoingeckoID)
+	}
+
+	ids, err := s.coinRepo.GetIDsByCoingeckoIDs(coingeckoIDs)
+	if err != nil {
+		return err
+	}
+
+	quotes := make([]domain.CoinQuote, 0, len(coins))
+	for _, c := range coins {
+		if coinID, ok := ids[c.CoingeckoID]; ok {
+			quotes = append(quotes, domain.NewCoinQuote(coinID, vsCurrency, c))
+		}
+	}
+	if err := s.quoteRepo.UpsertBatch(quotes); err != nil {
+		return err
+	}
+	syncRunFromContext(ctx).addWritten(len(quotes))
+	return nil
+}
+
+// SyncCoinMarketData fetches all tickers of a specific coin and stores them as normalized market data rows
+func (s *coinService) SyncCoinMarketData(ctx context.Context, coinID string) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindCoinMarketData)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().WithField("coin_id", coinID).Info("Starting coin market data synchronization")
 
 	// Get the coin from database
 	coin, err := s.coinRepo.GetByCoingeckoID(coinID)
@@ -140,69 +395,130 @@ func (s *coinService) SyncCoinMarketData(coinID string) error {
 		return fmt.Errorf("coin with ID %s not found in database", coinID)
 	}
 
-	// Get current market data for this coin
-	currentMarketData, err := s.coinMarketDataRepo.GetByCoinID(coin.ID)
+	// Tickers reference exchanges by their CoinGecko identifier
+	exchangeIDs, err := s.exchangeIDsByCoingeckoID()
 	if err != nil {
-		logger.GetLogger().WithError(err).WithField("coin_id", coinID).Error("Failed to get current market data from database")
-		return fmt.Errorf("failed to get current market data: %w", err)
+		logger.GetLogger().WithError(err).Error("Failed to get exchanges from database")
+		return err
 	}
-	logger.GetLogger().WithFields(map[string]interface{}{
-		"coin_id":       coinID,
-		"current_count": len(currentMarketData),
-	}).Info("Current market data in database")
 
-	// Fetch market data from CoinGecko API
-	apiMarketData, err := s.coingeckoClient.GetCoinMarketData(ctx, coinID)
-	if err != nil {
-		logger.GetLogger().WithError(err).WithField("coin_id", coinID).Error("Failed to fetch market data from API")
-		return fmt.Errorf("failed to fetch market data: %w", err)
+	syncStart := time.Now()
+
+	// Fetch every ticker page from CoinGecko API
+	var tickers []TickerResponse
+	for page := 1; ; page++ {
+		pageTickers, err := s.coingeckoClient.GetCoinMarketData(ctx, coinID, page)
+		if err != nil {
+			logger.GetLogger().WithError(err).WithField("coin_id", coinID).Error("Failed to fetch market data from API")
+			return fmt.Errorf("failed to fetch market data: %w", err)
+		}
+		if len(pageTickers) == 0 {
+			break
+		}
+		tickers = append(tickers, pageTickers...)
+	}
+
+	marketData := tickersToMarketData(coin.ID, tickers, exchangeIDs)
+	run.addSkipped(len(tickers) - len(marketData))
+	if err := s.storeMarketData(coin.ID, marketData, syncStart); err != nil {
+		logger.GetLogger().WithError(err).WithField("coin_id", coinID).Error("Failed to store market data in database")
+		return err
 	}
+	run.addWritten(len(marketData))
 
-	// Get all exchanges to map exchange names to IDs
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"coin_id": coinID,
+		"tickers": len(tickers),
+		"count":   len(marketData),
+	}).Info("Coin market data synchronization completed successfully")
+	return nil
+}
+
+// exchangeIDsByCoingeckoID maps exchange CoinGecko identifiers, as used by tickers, to database IDs
+func (s *coinService) exchangeIDsByCoingeckoID() (map[string]uint, error) {
 	exchanges, err := s.exchangeRepo.GetAll()
 	if err != nil {
-		logger.GetLogger().WithError(err).Error("Failed to get exchanges from database")
-		return fmt.Errorf("failed to get exchanges: %w", err)
+		return nil, fmt.Errorf("failed to get exchanges: %w", err)
 	}
 
-	// Create exchange name to ID mapping
-	exchangeMap := make(map[string]uint)
+	ids := make(map[string]uint, len(exchanges))
 	for _, exchange := range exchanges {
-		exchangeMap[exchange.Name] = exchange.ID
+		ids[exchange.CoingeckoID] = exchange.ID
 	}
+	return ids, nil
+}
 
-	// Process and store market data
-	validMarketData := make([]domain.CoinMarketData, 0, len(apiMarketData))
-	for range apiMarketData {
-		// Find exchange ID by name (this would need to be enhanced based on the actual API response structure)
-		// For now, we'll skip this as the API response structure needs to be refined
-		// This is a placeholder implementation
-		logger.GetLogger().WithField("coin_id", coinID).Debug("Processing market data entry")
-	}
+// tickersToMarketData converts tickers into market data rows for a coin.
+// Tickers without a last price or on exchanges that are not synced yet are skipped.
+func tickersToMarketData(coinID uint, tickers []TickerResponse, exchangeIDs map[string]uint) []domain.CoinMarketData {
+	marketData := make([]domain.CoinMarketData, 0, len(tickers))
+	unknownExchanges := make(map[string]bool)
 
-	// Store market data in the database
-	if len(validMarketData) > 0 {
-		if err := s.coinMarketDataRepo.UpsertBatch(validMarketData); err != nil {
-			logger.GetLogger().WithError(err).WithField("coin_id", coinID).Error("Failed to store market data in database")
-			return fmt.Errorf("failed to store market data: %w", err)
+	for _, ticker := range tickers {
+		if ticker.Last == nil {
+			continue
+		}
+		exchangeID, ok := exchangeIDs[ticker.Market.Identifier]
+		if !ok {
+			unknownExchanges[ticker.Market.Identifier] = true
+			continue
 		}
+
+		marketData = append(marketData, domain.CoinMarketData{
+			CoinID:                 coinID,
+			ExchangeID:             exchangeID,
+			Base:                   ticker.Base,
+			Target:                 ticker.Target,
+			Source:                 domain.MarketDataSourceCoin,
+			Price:                  ticker.Last,
+			Volume24h:              ticker.Volume,
+			ConvertedLastUSD:       ticker.ConvertedLast.USD,
+			ConvertedVolumeUSD:     ticker.ConvertedVolume.USD,
+			BidAskSpreadPercentage: ticker.BidAskSpreadPercentage,
+			TrustScore:             ticker.TrustScore,
+			IsAnomaly:              ticker.IsAnomaly,
+			IsStale:                ticker.IsStale,
+			LastUpdated:            ticker.Timestamp,
+			LastTradedAt:           ticker.LastTradedAt,
+			LastFetchAt:            ticker.LastFetchAt,
+		})
 	}
 
-	logger.GetLogger().WithFields(map[string]interface{}{
-		"coin_id": coinID,
-		"count":   len(validMarketData),
-	}).Info("Successfully fetched and stored coin market data")
+	if len(unknownExchanges) > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"coin_id":   coinID,
+			"exchanges": len(unknownExchanges),
+		}).Debug("Skipped tickers on exchanges missing from the database; sync exchanges to include them")
+	}
+
+	return marketData
+}
 
-	logger.GetLogger().WithField("coin_id", coinID).Info("Coin market data synchronization completed successfully")
+// storeMarketData upserts a coin's tickers and removes the ones CoinGecko no longer lists.
+// Tickers last written by the exchanges data sync are left to that sync.
+func (s *coinService) storeMarketData(coinID uint, marketData []domain.CoinMarketData, syncStart time.Time) error {
+	if err := s.coinMarketDataRepo.UpsertBatch(marketData); err != nil {
+		return fmt.Errorf("failed to store market data: %w", err)
+	}
+	if err := s.coinMarketDataRepo.DeleteStaleByCoinID(coinID, domain.MarketDataSourceCoin, syncStart); err != nil {
+		return err
+	}
 	return nil
 }
 
-// SyncCoinsData fetches detailed coin data and tickers for coins above a volume threshold
-func (s *coinService) SyncCoinsData(minTotalVolume float64) error {
-	logger.GetLogger().WithField("min_total_volume", minTotalVolume).Info("Starting coins data synchronization")
+// SyncCoinsData fetches detailed coin data and tickers for coins above a volume threshold.
+// Coins are started in CoinGecko ID order, up to opts.Workers at a time, and progress is checkpointed as coins
+// finish, so a run that reaches its deadline or is cancelled resumes where it stopped; a full pass clears the checkpoint.
+func (s *coinService) SyncCoinsData(ctx context.Context, opts CoinsDataOptions) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindCoinsData)
+	defer func() { run.finish(err) }()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 600*time.Second)
-	defer cancel()
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"min_total_volume": opts.MinTotalVolume,
+		"freshness":        opts.Freshness.String(),
+		"max_duration":     opts.MaxDuration.String(),
+		"workers":          opts.Workers,
+	}).Info("Starting coins data synchronization")
 
 	// Load coins and filter by volume
 	coins, err := s.coinRepo.GetAll()
@@ -210,90 +526,360 @@ func (s *coinService) SyncCoinsData(minTotalVolume float64) error {
 		return fmt.Errorf("failed to load coins: %w", err)
 	}
 
-	filtered := make([]domain.Coin, 0, len(coins))
-	for _, c := range coins {
-		if c.TotalVolume != nil && *c.TotalVolume >= minTotalVolume {
-			filtered = append(filtered, c)
-		}
-	}
+	filtered := filterByMinTotalVolume(coins, opts.MinTotalVolume)
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].CoingeckoID < filtered[j].CoingeckoID })
 
 	logger.GetLogger().WithFields(map[string]interface{}{
 		"eligible": len(filtered),
 		"total":    len(coins),
 	}).Info("Coins eligible for detailed sync by volume filter")
 
-	// For each coin, fetch coin data and tickers; store raw JSON in coin_details
-	for _, c := range filtered {
-		// Fetch /coins/{id}
-		data, err := s.coingeckoClient.GetCoinDataByID(ctx, c.CoingeckoID)
-		if err != nil {
-			logger.GetLogger().WithError(err).WithField("coin_id", c.CoingeckoID).Warn("Failed to fetch coin data by id; skipping")
-			continue
-		}
+	// Tickers reference exchanges by their CoinGecko identifier
+	exchangeIDs, err := s.exchangeIDsByCoingeckoID()
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := s.checkpointRepo.Get(domain.SyncKindCoinsData)
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"coin_id": checkpoint.CoingeckoID,
+			"page":    checkpoint.Page,
+		}).Info("Resuming coins data synchronization from checkpoint")
+	}
 
-		// Save coin detail
-		raw, _ := json.Marshal(data)
-		detail := domain.CoinDetail{
-			CoinID:      c.ID,
-			CoingeckoID: c.CoingeckoID,
-			RawJSON:     raw,
+	fresh := map[uint]bool{}
+	if opts.Freshness > 0 {
+		fresh, err = s.coinDetailRepo.GetFreshCoinIDs(time.Now().Add(-opts.Freshness))
+		if err != nil {
+			return err
 		}
+	}
 
-		// Optional denormalized fields
-		if v, ok := data["genesis_date"].(string); ok && v != "" {
-			if t, parseErr := time.Parse(time.RFC3339, v+"T00:00:00Z"); parseErr == nil {
-				detail.GenesisDate = &t
+	// Work out which coins this run still has to visit and where each one starts
+	var pending []domain.Coin
+	var startPages []int
+	skippedFresh := 0
+	for _, c := range filtered {
+		startPage := 1
+		if checkpoint != nil {
+			switch {
+			case c.CoingeckoID < checkpoint.CoingeckoID, c.CoingeckoID == checkpoint.CoingeckoID && checkpoint.Page == 0:
+				// Already synced by an earlier run of this pass
+				continue
+			case c.CoingeckoID == checkpoint.CoingeckoID:
+				startPage = checkpoint.Page
 			}
 		}
-		if v, ok := data["hashing_algorithm"].(string); ok {
-			detail.HashingAlgo = &v
+		if startPage == 1 && fresh[c.ID] {
+			skippedFresh++
+			continue
 		}
-		if cats, ok := data["categories"].([]any); ok {
-			if b, mErr := json.Marshal(cats); mErr == nil {
-				detail.Categories = b
+		pending = append(pending, c)
+		startPages = append(startPages, startPage)
+	}
+
+	deadline := newSyncDeadline(ctx, opts.MaxDuration)
+	results := make([]*coinDataResult, len(pending))
+	var processed, failed, done int
+	var spent time.Duration
+
+	runBounded(len(pending), opts.Workers,
+		func(int) bool {
+			// Another coin of average duration must also fit before the deadline
+			return ctx.Err() == nil && deadline.allows(averageDuration(spent, processed))
+		},
+		func(i int) coinDataResult {
+			began := time.Now()
+			resumePage, err := s.syncCoinData(ctx, pending[i], startPages[i], exchangeIDs, deadline)
+			return coinDataResult{resumePage: resumePage, err: err, duration: time.Since(began)}
+		},
+		func(i int, r coinDataResult) {
+			results[i] = &r
+			spent += r.duration
+			processed++
+			if r.err != nil {
+				failed++
+				logger.GetLogger().WithError(r.err).WithField("coin_id", pending[i].CoingeckoID).Warn("Failed to sync coin data")
 			}
-		}
-		if links, ok := data["links"].(map[string]any); ok {
-			if hp, ok2 := links["homepage"].([]any); ok2 {
-				if b, mErr := json.Marshal(hp); mErr == nil {
-					detail.Homepage = b
-				}
+
+			// The checkpoint only moves past coins that finished along with every coin before them
+			advanced := done
+			for done < len(pending) && results[done] != nil && results[done].resumePage == 0 {
+				done++
 			}
+			if done > advanced {
+				s.saveCheckpoint(pending[done-1].CoingeckoID, 0)
+			}
+		},
+	)
+
+	if done < len(pending) {
+		// Continue the first unfinished coin from its first unread page; coins finished after it
+		// have fresh details and are skipped next time unless the freshness window is disabled
+		if r := results[done]; r != nil && r.resumePage > 0 {
+			s.saveCheckpoint(pending[done].CoingeckoID, r.resumePage)
+		}
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("coins data synchronization interrupted: %w", err)
 		}
+		run.markPartial()
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"processed":     processed,
+			"failed":        failed,
+			"skipped_fresh": skippedFresh,
+			"remaining":     len(pending) - done,
+		}).Info("Coins data synchronization reached its deadline; the next run resumes from the checkpoint")
+		return nil
+	}
+
+	// The pass is complete, so the next run starts again from the first coin
+	if err := s.checkpointRepo.Delete(domain.SyncKindCoinsData); err != nil {
+		return err
+	}
+
+	stats := s.coingeckoClient.RateLimitStats()
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"processed":     processed,
+		"failed":        failed,
+		"skipped_fresh": skippedFresh,
+		"workers":       opts.Workers,
+		"api_calls":     stats.Calls,
+		"throttled":     stats.Throttled,
+		"rate_waited":   stats.Waited.String(),
+	}).Info("Coins data synchronization completed")
+	return nil
+}
+
+// coinDataResult is the outcome of syncing one coin in SyncCoinsData
+type coinDataResult struct {
+	resumePage int // 0 when the coin is done, otherwise the ticker page to continue from
+	err        error
+	duration   time.Duration
+}
 
-		if lu, ok := data["last_updated"].(string); ok && lu != "" {
-			if t, perr := time.Parse(time.RFC3339, lu); perr == nil {
-				detail.LastUpdatedAt = &t
+// syncCoinData stores one coin's details and tickers, starting at the given ticker page.
+// It returns 0 once the coin is done, or the ticker page to resume from when the context was
+// cancelled or the deadline leaves no room for another page. Details are only fetched from page 1.
+// It is safe to run for several coins at once; an error only concerns this coin.
+func (s *coinService) syncCoinData(ctx context.Context, c domain.Coin, startPage int, exchangeIDs map[string]uint, deadline syncDeadline) (int, error) {
+	run := syncRunFromContext(ctx)
+
+	if startPage == 1 {
+		if err := s.storeCoinDetail(ctx, c); err != nil {
+			if ctx.Err() != nil {
+				return 1, nil
 			}
+			run.addSkipped(1)
+			return 0, err
 		}
+	}
 
-		if err := s.coinDetailRepo.Upsert(detail); err != nil {
-			logger.GetLogger().WithError(err).WithField("coin_id", c.CoingeckoID).Warn("Failed to upsert coin detail")
+	// Fetch tickers with pagination (100 per page); keep the raw pages and normalize them into coin_market_data.
+	// Pages read by an earlier run are not re-read, so a resumed coin cannot tell which tickers were delisted.
+	syncStart := time.Now()
+	var tickers []TickerResponse
+	var pageTime time.Duration
+	complete := startPage == 1
+	resumePage := 0
+	for page := startPage; ; page++ {
+		if fetched := page - startPage; fetched > 0 && !deadline.allows(averageDuration(pageTime, fetched)) {
+			resumePage = page
+			break
 		}
 
-		// Fetch tickers with pagination (100 per page). Persisting raw is sufficient for now.
-		page := 1
-		for {
-			tickersPayload, err := s.coingeckoClient.GetCoinTickers(ctx, c.CoingeckoID, page)
-			if err != nil {
-				logger.GetLogger().WithError(err).WithFields(map[string]interface{}{"coin_id": c.CoingeckoID, "page": page}).Warn("Failed to fetch tickers; stopping pagination")
+		began := time.Now()
+		response, raw, err := s.coingeckoClient.GetCoinTickers(ctx, c.CoingeckoID, page)
+		if err != nil {
+			if ctx.Err() != nil {
+				resumePage = page
 				break
 			}
-			// persist
-			if b, mErr := json.Marshal(tickersPayload); mErr == nil {
-				_ = s.coinTickerRepo.Upsert(domain.CoinTicker{CoinID: c.ID, Page: page, RawJSON: b})
+			logger.GetLogger().WithError(err).WithFields(map[string]interface{}{"coin_id": c.CoingeckoID, "page": page}).Warn("Failed to fetch tickers; stopping pagination")
+			complete = false
+			break
+		}
+		pageTime += time.Since(began)
+
+		// A page that could not be stored does not count as read, so nothing is pruned on its account
+		if err := s.coinTickerRepo.Upsert(domain.CoinTicker{CoinID: c.ID, Page: page, RawJSON: raw}); err != nil {
+			logger.GetLogger().WithError(err).WithFields(map[string]interface{}{"coin_id": c.CoingeckoID, "page": page}).Warn("Failed to store tickers page")
+			run.addSkipped(1)
+			complete = false
+		}
+		tickers = append(tickers, response.Tickers...)
+
+		// Stop if no tickers returned
+		if len(response.Tickers) == 0 {
+			break
+		}
+	}
+	if resumePage > 0 {
+		complete = false
+	}
+
+	// Only prune tickers when every page was read, otherwise unread pages would look delisted
+	marketData := tickersToMarketData(c.ID, tickers, exchangeIDs)
+	run.addSkipped(len(tickers) - len(marketData))
+	var err error
+	if complete {
+		err = s.storeMarketData(c.ID, marketData, syncStart)
+	} else {
+		err = s.coinMarketDataRepo.UpsertBatch(marketData)
+	}
+	if err != nil {
+		return resumePage, fmt.Errorf("failed to store normalized tickers: %w", err)
+	}
+	run.addWritten(len(marketData))
+	return resumePage, nil
+}
+
+// storeCoinDetail fetches /coins/{id} and stores it in coin_details along with the coin's category memberships and contracts.
+// Only the fetch error is returned; a failed upsert is logged so the coin's tickers are still synced.
+func (s *coinService) storeCoinDetail(ctx context.Context, c domain.Coin) error {
+	data, err := s.coingeckoClient.GetCoinDataByID(ctx, c.CoingeckoID)
+	if err != nil {
+		return err
+	}
+
+	raw, _ := json.Marshal(data)
+	detail := domain.CoinDetail{
+		CoinID:      c.ID,
+		CoingeckoID: c.CoingeckoID,
+		RawJSON:     raw,
+	}
+
+	// Optional denormalized fields
+	if v, ok := data["genesis_date"].(string); ok && v != "" {
+		if t, parseErr := time.Parse(time.RFC3339, v+"T00:00:00Z"); parseErr == nil {
+			detail.GenesisDate = &t
+		}
+	}
+	if v, ok := data["hashing_algorithm"].(string); ok {
+		detail.HashingAlgo = &v
+	}
+	var categoryNames []string
+	if cats, ok := data["categories"].([]any); ok {
+		if b, mErr := json.Marshal(cats); mErr == nil {
+			detail.Categories = b
+		}
+		for _, cat := range cats {
+			if name, ok := cat.(string); ok {
+				categoryNames = append(categoryNames, name)
 			}
-			// We currently do not persist tickers separately; this is a placeholder to extend later.
-			// Stop if no tickers returned
-			if arr, ok := tickersPayload["tickers"].([]any); !ok || len(arr) == 0 {
-				break
+		}
+	}
+	if links, ok := data["links"].(map[string]any); ok {
+		if hp, ok2 := links["homepage"].([]any); ok2 {
+			if b, mErr := json.Marshal(hp); mErr == nil {
+				detail.Homepage = b
 			}
-			// Next page
-			page++
-			time.Sleep(500 * time.Millisecond)
 		}
 	}
 
-	logger.GetLogger().Info("Coins data synchronization completed")
+	if lu, ok := data["last_updated"].(string); ok && lu != "" {
+		if t, perr := time.Parse(time.RFC3339, lu); perr == nil {
+			detail.LastUpdatedAt = &t
+		}
+	}
+
+	if err := s.coinDetailRepo.Upsert(detail); err != nil {
+		logger.GetLogger().WithError(err).WithField("coin_id", c.CoingeckoID).Warn("Failed to upsert coin detail")
+		return nil
+	}
+	syncRunFromContext(ctx).addWritten(1)
+
+	// Details name categories instead of giving their ids; names missing from coin_categories stay unlinked
+	linked, err := s.membershipRepo.ReplaceForCoin(c.ID, categoryNames)
+	if err != nil {
+		logger.GetLogger().WithError(err).WithField("coin_id", c.CoingeckoID).Warn("Failed to store coin category memberships")
+	} else if linked < len(categoryNames) {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"coin_id":    c.CoingeckoID,
+			"categories": len(categoryNames),
+			"linked":     linked,
+		}).Debug("Some coin categories are not in coin_categories")
+	}
+
+	if err := s.contractRepo.ReplaceForCoin(c.ID, coinContracts(data)); err != nil {
+		logger.GetLogger().WithError(err).WithField("coin_id", c.CoingeckoID).Warn("Failed to store coin contracts")
+	}
 	return nil
 }
+
+// coinContracts reads the contract addresses from a /coins/{id} payload.
+// Native coins list an empty platform, which is left out; decimals come from detail_platforms when present.
+func coinContracts(data map[string]any) []domain.CoinContract {
+	platforms, _ := data["platforms"].(map[string]any)
+	details, _ := data["detail_platforms"].(map[string]any)
+
+	contracts := make([]domain.CoinContract, 0, len(platforms))
+	for platformID, v := range platforms {
+		address, _ := v.(string)
+		if platformID == "" || strings.TrimSpace(address) == "" {
+			continue
+		}
+		contract := domain.CoinContract{AssetPlatformID: platformID, ContractAddress: address}
+		if detail, ok := details[platformID].(map[string]any); ok {
+			if decimals, ok := detail["decimal_place"].(float64); ok {
+				d := int(decimals)
+				contract.DecimalPlace = &d
+			}
+		}
+		contracts = append(contracts, contract)
+	}
+	return contracts
+}
+
+// saveCheckpoint records coins data progress; a failed write only means the next run repeats some work
+func (s *coinService) saveCheckpoint(coingeckoID string, page int) {
+	checkpoint := domain.SyncCheckpoint{Kind: domain.SyncKindCoinsData, CoingeckoID: coingeckoID, Page: page}
+	if err := s.checkpointRepo.Save(checkpoint); err != nil {
+		logger.GetLogger().WithError(err).WithField("coin_id", coingeckoID).Warn("Failed to save coins data checkpoint")
+	}
+}
+
+// syncDeadline is the time by which a long sync should stop so it can checkpoint instead of being cut off mid-coin
+type syncDeadline struct {
+	at time.Time // zero when there is no limit
+}
+
+// newSyncDeadline takes the earlier of the context deadline and maxDuration from now; a zero maxDuration adds no limit
+func newSyncDeadline(ctx context.Context, maxDuration time.Duration) syncDeadline {
+	var d syncDeadline
+	if maxDuration > 0 {
+		d.at = time.Now().Add(maxDuration)
+	}
+	if at, ok := ctx.Deadline(); ok && (d.at.IsZero() || at.Before(d.at)) {
+		d.at = at
+	}
+	return d
+}
+
+// allows reports whether a step expected to take about next still fits before the deadline, with half of it again as margin
+func (d syncDeadline) allows(next time.Duration) bool {
+	return d.at.IsZero() || time.Until(d.at) > next+next/2
+}
+
+// averageDuration returns total divided by n, or zero when nothing has been measured yet
+func averageDuration(total time.Duration, n int) time.Duration {
+	if n == 0 {
+		return 0
+	}
+	return total / time.Duration(n)
+}
+
+// filterByMinTotalVolume keeps the coins whose 24h total volume is at least minTotalVolume
+func filterByMinTotalVolume(coins []domain.Coin, minTotalVolume float64) []domain.Coin {
+	filtered := make([]domain.Coin, 0, len(coins))
+	for _, c := range coins {
+		// Inactive coins keep the last volume they were listed with but no longer trade
+		if c.Active && c.TotalVolume != nil && *c.TotalVolume >= minTotalVolume {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"cgoffline/internal/domain"
+)
+
+func TestCoinContracts(t *testing.T) {
+	payload := `{
+		"platforms": {
+			"": "",
+			"ethereum": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+			"solana": "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+			"tron": "  ",
+			"bad": 42
+		},
+		"detail_platforms": {
+			"ethereum": {"decimal_place": 6, "contract_address": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"},
+			"solana": {"decimal_place": null, "contract_address": "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"}
+		}
+	}`
+	var data map[string]any
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	contracts := coinContracts(data)
+	sort.Slice(contracts, func(i, j int) bool { return contracts[i].AssetPlatformID < contracts[j].AssetPlatformID })
+
+	if len(contracts) != 2 {
+		t.Fatalf("coinContracts() returned %d contracts, want 2: %+v", len(contracts), contracts)
+	}
+
+	eth := contracts[0]
+	if eth.AssetPlatformID != "ethereum" || eth.ContractAddress != "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48" {
+		t.Errorf("ethereum contract = %+v", eth)
+	}
+	if eth.DecimalPlace == nil || *eth.DecimalPlace != 6 {
+		t.Errorf("ethereum decimal place = %v, want 6", eth.DecimalPlace)
+	}
+
+	sol := contracts[1]
+	if sol.AssetPlatformID != "solana" || sol.ContractAddress != "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v" {
+		t.Errorf("solana contract = %+v", sol)
+	}
+	if sol.DecimalPlace != nil {
+		t.Errorf("solana decimal place = %d, want nil", *sol.DecimalPlace)
+	}
+}
+
+func TestCoinContractsNativeCoin(t *testing.T) {
+	data := map[string]any{"platforms": map[string]any{"": ""}}
+	if contracts := coinContracts(data); len(contracts) != 0 {
+		t.Errorf("coinContracts() of a native coin = %+v, want none", contracts)
+	}
+	if contracts := coinContracts(map[string]any{}); len(contracts) != 0 {
+		t.Errorf("coinContracts() without platforms = %+v, want none", contracts)
+	}
+}
+
+func TestMarketDataSources(t *testing.T) {
+	last := 1.5
+	ticker := TickerResponse{Base: "BTC", Target: "USDT", Last: &last, CoinID: "bitcoin"}
+	ticker.Market.Identifier = "binance"
+	tickers := []TickerResponse{ticker, {Base: "BTC", Target: "EUR", CoinID: "bitcoin"}}
+
+	fromCoin := tickersToMarketData(1, tickers, map[string]uint{"binance": 2})
+	if len(fromCoin) != 1 || fromCoin[0].Source != domain.MarketDataSourceCoin || fromCoin[0].ExchangeID != 2 {
+		t.Errorf("tickersToMarketData() = %+v, want one coin-sourced ticker on exchange 2", fromCoin)
+	}
+
+	fromExchange := exchangeTickersToMarketData(2, tickers, map[string]uint{"bitcoin": 1})
+	if len(fromExchange) != 1 || fromExchange[0].Source != domain.MarketDataSourceExchange || fromExchange[0].CoinID != 1 {
+		t.Errorf("exchangeTickersToMarketData() = %+v, want one exchange-sourced ticker of coin 1", fromExchange)
+	}
+}
@@ -3,9 +3,12 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"cgoffline/internal/domain"
@@ -13,24 +16,158 @@ import (
 	"cgoffline/pkg/logger"
 )
 
+// API key headers expected by CoinGecko for each paid plan
+const (
+	demoAPIKeyHeader = "x-cg-demo-api-key"
+	proAPIKeyHeader  = "x-cg-pro-api-key"
+)
+
+// ErrNotFound is returned when CoinGecko answers 404, for instance for a contract address it does not list
+var ErrNotFound = errors.New("not found on CoinGecko")
+
 // CoinGeckoClient handles communication with the CoinGecko API
 type CoinGeckoClient struct {
-	baseURL    string
-	httpClient *http.Client
-	retryCount int
-	retryDelay time.Duration
+	baseURL      string
+	httpClient   *http.Client
+	retryCount   int
+	retryDelay   time.Duration
+	limiter      *RateLimiter
+	apiKey       string
+	apiKeyHeader string
 }
 
 // NewCoinGeckoClient creates a new CoinGecko API client
 func NewCoinGeckoClient(cfg config.APIConfig) *CoinGeckoClient {
-	return &CoinGeckoClient{
+	client := &CoinGeckoClient{
 		baseURL: cfg.CoinGeckoBaseURL,
 		httpClient: &http.Client{
 			Timeout: cfg.Timeout,
 		},
 		retryCount: cfg.RetryAttempts,
 		retryDelay: cfg.RetryDelay,
+		limiter:    NewRateLimiter(cfg.CallsPerMinute, cfg.RateLimitBurst),
+		apiKey:     cfg.APIKey,
+	}
+
+	switch cfg.Plan {
+	case config.PlanDemo:
+		client.apiKeyHeader = demoAPIKeyHeader
+	case config.PlanPro:
+		client.apiKeyHeader = proAPIKeyHeader
+		// Pro keys are only accepted on the pro host; keep any custom base URL as configured
+		if client.baseURL == config.PublicCoinGeckoBaseURL {
+			client.baseURL = config.ProCoinGeckoBaseURL
+		}
+	}
+
+	return client
+}
+
+// redact removes the API key from text that may end up in logs or errors
+func (c *CoinGeckoClient) redact(s string) string {
+	if c.apiKey == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, c.apiKey, "[REDACTED]")
+}
+
+// redactedError hides the API key in an error message while keeping the original error for errors.Is/As
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// RateLimitStats reports how many calls the client has made and how long it has waited for the rate limiter
+func (c *CoinGeckoClient) RateLimitStats() RateLimitStats {
+	return c.limiter.Stats()
+}
+
+// do sends a request through the shared rate limiter.
+// A 429 response pauses the limiter for the duration given in Retry-After so every caller backs off.
+func (c *CoinGeckoClient) do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	if err := c.limiter.Wait(req.Context()); err != nil {
+		return nil, fmt.Errorf("rate limiter wait failed: %w", err)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		logger.GetLogger().WithField("waited", waited.String()).Debug("Waited for rate limiter")
+	}
+
+	if c.apiKey != "" && c.apiKeyHeader != "" {
+		req.Header.Set(c.apiKeyHeader, c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	run := syncRunFromContext(req.Context())
+	if err != nil {
+		run.recordCall(false)
+		return nil, &redactedError{msg: c.redact(err.Error()), err: err}
+	}
+	run.recordCall(resp.StatusCode == http.StatusOK)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		c.limiter.PauseFor(retryAfter)
+		logger.GetLogger().WithField("retry_after", retryAfter.String()).Warn("CoinGecko API rate limit hit; pausing requests")
+	}
+
+	return resp, nil
+}
+
+// getJSON performs a GET request with retries and decodes the JSON response into out
+func (c *CoinGeckoClient) getJSON(ctx context.Context, url string, out any) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.retryCount; attempt++ {
+		if attempt > 0 {
+			logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
+			if err := sleepContext(ctx, c.retryDelay); err != nil {
+				lastErr = err
+				break
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", "cgoffline/1.0")
+
+		resp, err := c.do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to execute request: %w", err)
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response body: %w", err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			// Retrying cannot make an unknown resource appear
+			return fmt.Errorf("%w: %s", ErrNotFound, c.redact(string(body)))
+		}
+		if resp.StatusCode != http.StatusOK {
+			lastErr = fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, c.redact(string(body)))
+			continue
+		}
+
+		if err := json.Unmarshal(body, out); err != nil {
+			lastErr = fmt.Errorf("failed to unmarshal response: %w", err)
+			continue
+		}
+
+		return nil
 	}
+
+	return lastErr
 }
 
 // AssetPlatformResponse represents the response structure from CoinGecko API
@@ -38,7 +175,7 @@ type AssetPlatformResponse struct {
 	ID              string  `json:"id"`
 	ChainIdentifier *int64  `json:"chain_identifier"`
 	Name            string  `json:"name"`
-	ShortName       *string `json:"short_name"`
+	ShortName       *string `json:"shortname"`
 	NativeCoinID    *string `json:"native_coin_id"`
 }
 
@@ -66,31 +203,67 @@ type ExchangeResponse struct {
 
 // CoinResponse represents the response structure for coins from CoinGecko API
 type CoinResponse struct {
-	ID                           string     `json:"id"`
-	Symbol                       string     `json:"symbol"`
-	Name                         string     `json:"name"`
-	Image                        *string    `json:"image"`
-	CurrentPrice                 *float64   `json:"current_price"`
-	MarketCap                    *float64   `json:"market_cap"`
-	MarketCapRank                *int       `json:"market_cap_rank"`
-	FullyDilutedValuation        *float64   `json:"fully_diluted_valuation"`
-	TotalVolume                  *float64   `json:"total_volume"`
-	High24h                      *float64   `json:"high_24h"`
-	Low24h                       *float64   `json:"low_24h"`
-	PriceChange24h               *float64   `json:"price_change_24h"`
-	PriceChangePercentage24h     *float64   `json:"price_change_percentage_24h"`
-	MarketCapChange24h           *float64   `json:"market_cap_change_24h"`
-	MarketCapChangePercentage24h *float64   `json:"market_cap_change_percentage_24h"`
-	CirculatingSupply            *float64   `json:"circulating_supply"`
-	TotalSupply                  *float64   `json:"total_supply"`
-	MaxSupply                    *float64   `json:"max_supply"`
-	Ath                          *float64   `json:"ath"`
-	AthChangePercentage          *float64   `json:"ath_change_percentage"`
-	AthDate                      *time.Time `json:"ath_date"`
-	Atl                          *float64   `json:"atl"`
-	AtlChangePercentage          *float64   `json:"atl_change_percentage"`
-	AtlDate                      *time.Time `json:"atl_date"`
-	LastUpdated                  *time.Time `json:"last_updated"`
+	ID                           string          `json:"id"`
+	Symbol                       string          `json:"symbol"`
+	Name                         string          `json:"name"`
+	Image                        *string         `json:"image"`
+	CurrentPrice                 *float64        `json:"current_price"`
+	MarketCap                    *float64        `json:"market_cap"`
+	MarketCapRank                *int            `json:"market_cap_rank"`
+	FullyDilutedValuation        *float64        `json:"fully_diluted_valuation"`
+	TotalVolume                  *float64        `json:"total_volume"`
+	High24h                      *float64        `json:"high_24h"`
+	Low24h                       *float64        `json:"low_24h"`
+	PriceChange24h               *float64        `json:"price_change_24h"`
+	PriceChangePercentage24h     *float64        `json:"price_change_percentage_24h"`
+	MarketCapChange24h           *float64        `json:"market_cap_change_24h"`
+	MarketCapChangePercentage24h *float64        `json:"market_cap_change_percentage_24h"`
+	CirculatingSupply            *float64        `json:"circulating_supply"`
+	TotalSupply                  *float64        `json:"total_supply"`
+	MaxSupply                    *float64        `json:"max_supply"`
+	Ath                          *float64        `json:"ath"`
+	AthChangePercentage          *float64        `json:"ath_change_percentage"`
+	AthDate                      *time.Time      `json:"ath_date"`
+	Atl                          *float64        `json:"atl"`
+	AtlChangePercentage          *float64        `json:"atl_change_percentage"`
+	AtlDate                      *time.Time      `json:"atl_date"`
+	ROI                          *domain.CoinROI `json:"roi"`
+	LastUpdated                  *time.Time      `json:"last_updated"`
+}
+
+// TickerResponse represents a single ticker from /coins/{id}/tickers
+type TickerResponse struct {
+	Base   string `json:"base"`
+	Target string `json:"target"`
+	Market struct {
+		Name       string `json:"name"`
+		Identifier string `json:"identifier"`
+	} `json:"market"`
+	Last          *float64 `json:"last"`
+	Volume        *float64 `json:"volume"`
+	ConvertedLast struct {
+		USD *float64 `json:"usd"`
+	} `json:"converted_last"`
+	ConvertedVolume struct {
+		USD *float64 `json:"usd"`
+	} `json:"converted_volume"`
+	TrustScore             *string    `json:"trust_score"`
+	BidAskSpreadPercentage *float64   `json:"bid_ask_spread_percentage"`
+	Timestamp              *time.Time `json:"timestamp"`
+	LastTradedAt           *time.Time `json:"last_traded_at"`
+	LastFetchAt            *time.Time `json:"last_fetch_at"`
+	IsAnomaly              bool       `json:"is_anomaly"`
+	IsStale                bool       `json:"is_stale"`
+	TradeURL               *string    `json:"trade_url"`
+	TokenInfoURL           *string    `json:"token_info_url"`
+	CoinID                 string     `json:"coin_id"`
+	TargetCoinID           string     `json:"target_coin_id"`
+}
+
+// CoinTickersResponse represents one page of /coins/{id}/tickers
+type CoinTickersResponse struct {
+	Name    string           `json:"name"`
+	Tickers []TickerResponse `json:"tickers"`
 }
 
 // CoinMarketDataResponse represents the response structure for coin market data from CoinGecko API
@@ -126,7 +299,7 @@ type CoinMarketDataResponse struct {
 func (c *CoinGeckoClient) GetAssetPlatforms(ctx context.Context) ([]domain.AssetPlatform, error) {
 	url := fmt.Sprintf("%s/asset_platforms", c.baseURL)
 
-	logger.GetLogger().WithField("url", url).Info("Fetching asset platforms from CoinGecko API")
+	logger.GetLogger().WithField("url", c.redact(url)).Info("Fetching asset platforms from CoinGecko API")
 
 	var platforms []domain.AssetPlatform
 	var lastErr error
@@ -135,7 +308,10 @@ func (c *CoinGeckoClient) GetAssetPlatforms(ctx context.Context) ([]domain.Asset
 	for attempt := 0; attempt <= c.retryCount; attempt++ {
 		if attempt > 0 {
 			logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
-			time.Sleep(c.retryDelay)
+			if err := sleepContext(ctx, c.retryDelay); err != nil {
+				lastErr = err
+				break
+			}
 		}
 
 		platforms, lastErr = c.fetchAssetPlatforms(ctx, url)
@@ -166,7 +342,7 @@ func (c *CoinGeckoClient) fetchAssetPlatforms(ctx context.Context, url string) (
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "cgoffline/1.0")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -174,7 +350,7 @@ func (c *CoinGeckoClient) fetchAssetPlatforms(ctx context.Context, url string) (
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, c.redact(string(body)))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -206,7 +382,7 @@ func (c *CoinGeckoClient) fetchAssetPlatforms(ctx context.Context, url string) (
 func (c *CoinGeckoClient) GetCoinCategories(ctx context.Context) ([]domain.CoinCategory, error) {
 	url := fmt.Sprintf("%s/coins/categories/list", c.baseURL)
 
-	logger.GetLogger().WithField("url", url).Info("Fetching coin categories from CoinGecko API")
+	logger.GetLogger().WithField("url", c.redact(url)).Info("Fetching coin categories from CoinGecko API")
 
 	var categories []domain.CoinCategory
 	var lastErr error
@@ -215,7 +391,10 @@ func (c *CoinGeckoClient) GetCoinCategories(ctx context.Context) ([]domain.CoinC
 	for attempt := 0; attempt <= c.retryCount; attempt++ {
 		if attempt > 0 {
 			logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
-			time.Sleep(c.retryDelay)
+			if err := sleepContext(ctx, c.retryDelay); err != nil {
+				lastErr = err
+				break
+			}
 		}
 
 		categories, lastErr = c.fetchCoinCategories(ctx, url)
@@ -246,7 +425,7 @@ func (c *CoinGeckoClient) fetchCoinCategories(ctx context.Context, url string) (
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "cgoffline/1.0")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -254,7 +433,7 @@ func (c *CoinGeckoClient) fetchCoinCategories(ctx context.Context, url string) (
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, c.redact(string(body)))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -279,37 +458,78 @@ func (c *CoinGeckoClient) fetchCoinCategories(ctx context.Context, url string) (
 	return categories, nil
 }
 
-// GetExchanges fetches all exchanges from CoinGecko API
+// CoinCategoryMarketResponse represents one category of /coins/categories
+type CoinCategoryMarketResponse struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	MarketCap          *float64 `json:"market_cap"`
+	MarketCapChange24h *float64 `json:"market_cap_change_24h"`
+	Content            string   `json:"content"`
+	Top3CoinsID        []string `json:"top_3_coins_id"`
+	Top3Coins          []string `json:"top_3_coins"`
+	Volume24h          *float64 `json:"volume_24h"`
+	UpdatedAt          string   `json:"updated_at"`
+}
+
+// GetCoinCategoriesMarket fetches every coin category with its market cap, 24h change, volume and top 3 coins
+// (/coins/categories), largest market cap first
+// Reference: https://docs.coingecko.com/v3.0.1/reference/coins-categories
+func (c *CoinGeckoClient) GetCoinCategoriesMarket(ctx context.Context) ([]CoinCategoryMarketResponse, error) {
+	reqURL := fmt.Sprintf("%s/coins/categories?order=market_cap_desc", c.baseURL)
+
+	logger.GetLogger().WithField("url", c.redact(reqURL)).Info("Fetching coin categories market data from CoinGecko API")
+
+	var categories []CoinCategoryMarketResponse
+	if err := c.getJSON(ctx, reqURL, &categories); err != nil {
+		return nil, fmt.Errorf("failed to fetch coin categories market data: %w", err)
+	}
+	return categories, nil
+}
+
+// GetExchanges fetches all exchanges from CoinGecko API, following pagination so the result is complete
 func (c *CoinGeckoClient) GetExchanges(ctx context.Context) ([]domain.Exchange, error) {
-	url := fmt.Sprintf("%s/exchanges", c.baseURL)
+	const perPage = 250
 
-	logger.GetLogger().WithField("url", url).Info("Fetching exchanges from CoinGecko API")
+	var all []domain.Exchange
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/exchanges?per_page=%d&page=%d", c.baseURL, perPage, page)
 
-	var exchanges []domain.Exchange
-	var lastErr error
+		logger.GetLogger().WithField("url", c.redact(url)).Info("Fetching exchanges from CoinGecko API")
 
-	// Retry logic
-	for attempt := 0; attempt <= c.retryCount; attempt++ {
-		if attempt > 0 {
-			logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
-			time.Sleep(c.retryDelay)
-		}
+		var exchanges []domain.Exchange
+		var lastErr error
 
-		exchanges, lastErr = c.fetchExchanges(ctx, url)
-		if lastErr == nil {
-			break
+		// Retry logic
+		for attempt := 0; attempt <= c.retryCount; attempt++ {
+			if attempt > 0 {
+				logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
+				if err := sleepContext(ctx, c.retryDelay); err != nil {
+					lastErr = err
+					break
+				}
+			}
+
+			exchanges, lastErr = c.fetchExchanges(ctx, url)
+			if lastErr == nil {
+				break
+			}
+
+			logger.GetLogger().WithError(lastErr).WithField("attempt", attempt).Warn("API request failed")
 		}
 
-		logger.GetLogger().WithError(lastErr).WithField("attempt", attempt).Warn("API request failed")
-	}
+		if lastErr != nil {
+			logger.GetLogger().WithError(lastErr).Error("Failed to fetch exchanges after all retry attempts")
+			return nil, fmt.Errorf("failed to fetch exchanges page %d: %w", page, lastErr)
+		}
 
-	if lastErr != nil {
-		logger.GetLogger().WithError(lastErr).Error("Failed to fetch exchanges after all retry attempts")
-		return nil, fmt.Errorf("failed to fetch exchanges: %w", lastErr)
+		all = append(all, exchanges...)
+		if len(exchanges) < perPage {
+			break
+		}
 	}
 
-	logger.GetLogger().WithField("count", len(exchanges)).Info("Successfully fetched exchanges from CoinGecko API")
-	return exchanges, nil
+	logger.GetLogger().WithField("count", len(all)).Info("Successfully fetched exchanges from CoinGecko API")
+	return all, nil
 }
 
 // fetchExchanges performs the actual HTTP request for exchanges
@@ -323,7 +543,7 @@ func (c *CoinGeckoClient) fetchExchanges(ctx context.Context, url string) ([]dom
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "cgoffline/1.0")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -331,7 +551,7 @@ func (c *CoinGeckoClient) fetchExchanges(ctx context.Context, url string) ([]dom
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, c.redact(string(body)))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -366,13 +586,13 @@ func (c *CoinGeckoClient) fetchExchanges(ctx context.Context, url string) ([]dom
 	return exchanges, nil
 }
 
-// GetCoins fetches coins with market data from CoinGecko API
-func (c *CoinGeckoClient) GetCoins(ctx context.Context, page int, perPage int) ([]domain.Coin, error) {
-	url := fmt.Sprintf("%s/coins/markets?vs_currency=usd&order=market_cap_desc&per_page=%d&page=%d&sparkline=false",
-		c.baseURL, perPage, page)
+// GetCoins fetches coins with market data quoted in vsCurrency from CoinGecko API
+func (c *CoinGeckoClient) GetCoins(ctx context.Context, vsCurrency string, page int, perPage int) ([]domain.Coin, error) {
+	url := fmt.Sprintf("%s/coins/markets?vs_currency=%s&order=market_cap_desc&per_page=%d&page=%d&sparkline=false",
+		c.baseURL, vsCurrency, perPage, page)
 
 	logger.GetLogger().WithFields(map[string]interface{}{
-		"url":      url,
+		"url":      c.redact(url),
 		"page":     page,
 		"per_page": perPage,
 	}).Info("Fetching coins from CoinGecko API")
@@ -384,7 +604,10 @@ func (c *CoinGeckoClient) GetCoins(ctx context.Context, page int, perPage int) (
 	for attempt := 0; attempt <= c.retryCount; attempt++ {
 		if attempt > 0 {
 			logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
-			time.Sleep(c.retryDelay)
+			if err := sleepContext(ctx, c.retryDelay); err != nil {
+				lastErr = err
+				break
+			}
 		}
 
 		coins, lastErr = c.fetchCoins(ctx, url)
@@ -415,7 +638,7 @@ func (c *CoinGeckoClient) fetchCoins(ctx context.Context, url string) ([]domain.
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "cgoffline/1.0")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -423,7 +646,7 @@ func (c *CoinGeckoClient) fetchCoins(ctx context.Context, url string) ([]domain.
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, c.redact(string(body)))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -443,6 +666,7 @@ func (c *CoinGeckoClient) fetchCoins(ctx context.Context, url string) ([]domain.
 			CoingeckoID:                  apiCoin.ID,
 			Symbol:                       apiCoin.Symbol,
 			Name:                         apiCoin.Name,
+			Active:                       true,
 			Image:                        apiCoin.Image,
 			CurrentPrice:                 apiCoin.CurrentPrice,
 			MarketCap:                    apiCoin.MarketCap,
@@ -464,6 +688,7 @@ func (c *CoinGeckoClient) fetchCoins(ctx context.Context, url string) ([]domain.
 			Atl:                          apiCoin.Atl,
 			AtlChangePercentage:          apiCoin.AtlChangePercentage,
 			AtlDate:                      apiCoin.AtlDate,
+			ROI:                          apiCoin.ROI,
 			LastUpdated:                  apiCoin.LastUpdated,
 		}
 	}
@@ -471,40 +696,24 @@ func (c *CoinGeckoClient) fetchCoins(ctx context.Context, url string) ([]domain.
 	return coins, nil
 }
 
-// GetCoinMarketData fetches market data for a specific coin from CoinGecko API
-func (c *CoinGeckoClient) GetCoinMarketData(ctx context.Context, coinID string) ([]domain.CoinMarketData, error) {
-	url := fmt.Sprintf("%s/coins/%s/tickers", c.baseURL, coinID)
+// GetCoinMarketData fetches one page of tickers (100 per page) for a specific coin from CoinGecko API (/coins/{id}/tickers)
+func (c *CoinGeckoClient) GetCoinMarketData(ctx context.Context, coinID string, page int) ([]TickerResponse, error) {
+	url := fmt.Sprintf("%s/coins/%s/tickers?page=%d", c.baseURL, coinID, page)
 
 	logger.GetLogger().WithFields(map[string]interface{}{
-		"url":     url,
+		"url":     c.redact(url),
 		"coin_id": coinID,
+		"page":    page,
 	}).Info("Fetching coin market data from CoinGecko API")
 
-	var marketData []domain.CoinMarketData
-	var lastErr error
-
-	// Retry logic
-	for attempt := 0; attempt <= c.retryCount; attempt++ {
-		if attempt > 0 {
-			logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
-			time.Sleep(c.retryDelay)
-		}
-
-		marketData, lastErr = c.fetchCoinMarketData(ctx, url, coinID)
-		if lastErr == nil {
-			break
-		}
-
-		logger.GetLogger().WithError(lastErr).WithField("attempt", attempt).Warn("API request failed")
+	var response CoinTickersResponse
+	if err := c.getJSON(ctx, url, &response); err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to fetch coin market data after all retry attempts")
+		return nil, fmt.Errorf("failed to fetch coin market data: %w", err)
 	}
 
-	if lastErr != nil {
-		logger.GetLogger().WithError(lastErr).Error("Failed to fetch coin market data after all retry attempts")
-		return nil, fmt.Errorf("failed to fetch coin market data: %w", lastErr)
-	}
-
-	logger.GetLogger().WithField("count", len(marketData)).Info("Successfully fetched coin market data from CoinGecko API")
-	return marketData, nil
+	logger.GetLogger().WithField("count", len(response.Tickers)).Info("Successfully fetched coin market data from CoinGecko API")
+	return response.Tickers, nil
 }
 
 // GetCoinDataByID fetches full coin data by ID from CoinGecko API (/coins/{id})
@@ -512,7 +721,7 @@ func (c *CoinGeckoClient) GetCoinDataByID(ctx context.Context, coinID string) (m
 	url := fmt.Sprintf("%s/coins/%s", c.baseURL, coinID)
 
 	logger.GetLogger().WithFields(map[string]interface{}{
-		"url":     url,
+		"url":     c.redact(url),
 		"coin_id": coinID,
 	}).Info("Fetching coin data by ID from CoinGecko API")
 
@@ -522,7 +731,10 @@ func (c *CoinGeckoClient) GetCoinDataByID(ctx context.Context, coinID string) (m
 	for attempt := 0; attempt <= c.retryCount; attempt++ {
 		if attempt > 0 {
 			logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
-			time.Sleep(c.retryDelay)
+			if err := sleepContext(ctx, c.retryDelay); err != nil {
+				lastErr = err
+				break
+			}
 		}
 
 		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -532,7 +744,7 @@ func (c *CoinGeckoClient) GetCoinDataByID(ctx context.Context, coinID string) (m
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("User-Agent", "cgoffline/1.0")
 
-		resp, err := c.httpClient.Do(req)
+		resp, err := c.do(req)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to execute request: %w", err)
 			continue
@@ -541,7 +753,7 @@ func (c *CoinGeckoClient) GetCoinDataByID(ctx context.Context, coinID string) (m
 
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
-			lastErr = fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+			lastErr = fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, c.redact(string(body)))
 			continue
 		}
 
@@ -562,162 +774,582 @@ func (c *CoinGeckoClient) GetCoinDataByID(ctx context.Context, coinID string) (m
 	return nil, fmt.Errorf("failed to fetch coin data by id: %w", lastErr)
 }
 
-// GetCoinTickers fetches coin tickers by ID from CoinGecko API (/coins/{id}/tickers)
+// GetCoinTickers fetches one page of coin tickers by ID from CoinGecko API (/coins/{id}/tickers).
+// The page is returned decoded and as the raw JSON CoinGecko sent, which is what coin_tickers keeps.
 // Reference: https://docs.coingecko.com/v3.0.1/reference/coins-id-tickers
-func (c *CoinGeckoClient) GetCoinTickers(ctx context.Context, coinID string, page int) (map[string]any, error) {
+func (c *CoinGeckoClient) GetCoinTickers(ctx context.Context, coinID string, page int) (*CoinTickersResponse, json.RawMessage, error) {
 	url := fmt.Sprintf("%s/coins/%s/tickers?page=%d", c.baseURL, coinID, page)
 
 	logger.GetLogger().WithFields(map[string]interface{}{
-		"url":     url,
+		"url":     c.redact(url),
 		"coin_id": coinID,
 		"page":    page,
 	}).Info("Fetching coin tickers by ID from CoinGecko API")
 
-	var lastErr error
+	var raw json.RawMessage
+	if err := c.getJSON(ctx, url, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch coin tickers: %w", err)
+	}
+
+	var response CoinTickersResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal coin tickers: %w", err)
+	}
+	return &response, raw, nil
+}
+
+// HealthCheck checks if the CoinGecko API is accessible
+func (c *CoinGeckoClient) HealthCheck(ctx context.Context) error {
+	url := fmt.Sprintf("%s/ping", c.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health check request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// MarketChartResponse represents the response structure for /coins/{id}/market_chart endpoints.
+// Each series holds [unix milliseconds, value] pairs; CoinGecko sends null for unknown values.
+type MarketChartResponse struct {
+	Prices       [][2]*float64 `json:"prices"`
+	MarketCaps   [][2]*float64 `json:"market_caps"`
+	TotalVolumes [][2]*float64 `json:"total_volumes"`
+}
+
+// GetCoinMarketChart fetches historical market data for the last given number of days (/coins/{id}/market_chart).
+// days accepts a number or "max"; granularity is chosen by CoinGecko from the range.
+// Reference: https://docs.coingecko.com/v3.0.1/reference/coins-id-market-chart
+func (c *CoinGeckoClient) GetCoinMarketChart(ctx context.Context, coinID string, vsCurrency string, days string) (*MarketChartResponse, error) {
+	url := fmt.Sprintf("%s/coins/%s/market_chart?vs_currency=%s&days=%s", c.baseURL, coinID, vsCurrency, days)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":     c.redact(url),
+		"coin_id": coinID,
+		"days":    days,
+	}).Info("Fetching coin market chart from CoinGecko API")
+
+	var chart MarketChartResponse
+	if err := c.getJSON(ctx, url, &chart); err != nil {
+		return nil, fmt.Errorf("failed to fetch coin market chart: %w", err)
+	}
+	return &chart, nil
+}
+
+// GetCoinMarketChartRange fetches historical market data between two times (/coins/{id}/market_chart/range).
+// CoinGecko picks the granularity from the range length: up to 1 day is 5-minutely, up to 90 days hourly, daily beyond.
+// Reference: https://docs.coingecko.com/v3.0.1/reference/coins-id-market-chart-range
+func (c *CoinGeckoClient) GetCoinMarketChartRange(ctx context.Context, coinID string, vsCurrency string, from, to time.Time) (*MarketChartResponse, error) {
+	url := fmt.Sprintf("%s/coins/%s/market_chart/range?vs_currency=%s&from=%d&to=%d", c.baseURL, coinID, vsCurrency, from.Unix(), to.Unix())
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":     c.redact(url),
+		"coin_id": coinID,
+		"from":    from.Format(time.RFC3339),
+		"to":      to.Format(time.RFC3339),
+	}).Info("Fetching coin market chart range from CoinGecko API")
+
+	var chart MarketChartResponse
+	if err := c.getJSON(ctx, url, &chart); err != nil {
+		return nil, fmt.Errorf("failed to fetch coin market chart range: %w", err)
+	}
+	return &chart, nil
+}
+
+// OHLCResponse represents the response structure for /coins/{id}/ohlc endpoints.
+// Each candle is [close time in unix milliseconds, open, high, low, close].
+type OHLCResponse [][5]float64
+
+// GetCoinOHLC fetches OHLC candles for the last given number of days (/coins/{id}/ohlc).
+// days must be one of 1, 7, 14, 30, 90, 180, 365 or max; the candle size follows from it:
+// 30 minutes for 1 day, 4 hours up to 30 days, 4 days beyond.
+// Reference: https://docs.coingecko.com/v3.0.1/reference/coins-id-ohlc
+func (c *CoinGeckoClient) GetCoinOHLC(ctx context.Context, coinID string, vsCurrency string, days string) (OHLCResponse, error) {
+	url := fmt.Sprintf("%s/coins/%s/ohlc?vs_currency=%s&days=%s", c.baseURL, coinID, vsCurrency, days)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":     c.redact(url),
+		"coin_id": coinID,
+		"days":    days,
+	}).Info("Fetching coin OHLC from CoinGecko API")
+
+	var candles OHLCResponse
+	if err := c.getJSON(ctx, url, &candles); err != nil {
+		return nil, fmt.Errorf("failed to fetch coin OHLC: %w", err)
+	}
+	return candles, nil
+}
+
+// GetCoinOHLCRange fetches hourly or daily OHLC candles between two times (/coins/{id}/ohlc/range).
+// Only available on paid plans; a request may span up to 31 days hourly or 180 days daily.
+// Reference: https://docs.coingecko.com/reference/coins-id-ohlc-range
+func (c *CoinGeckoClient) GetCoinOHLCRange(ctx context.Context, coinID string, vsCurrency string, from, to time.Time, interval string) (OHLCResponse, error) {
+	url := fmt.Sprintf("%s/coins/%s/ohlc/range?vs_currency=%s&from=%d&to=%d&interval=%s", c.baseURL, coinID, vsCurrency, from.Unix(), to.Unix(), interval)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":      c.redact(url),
+		"coin_id":  coinID,
+		"interval": interval,
+		"from":     from.Format(time.RFC3339),
+		"to":       to.Format(time.RFC3339),
+	}).Info("Fetching coin OHLC range from CoinGecko API")
+
+	var candles OHLCResponse
+	if err := c.getJSON(ctx, url, &candles); err != nil {
+		return nil, fmt.Errorf("failed to fetch coin OHLC range: %w", err)
+	}
+	return candles, nil
+}
+
+// GetSupportedVsCurrencies fetches the currency codes accepted as vs_currency from /simple/supported_vs_currencies
+func (c *CoinGeckoClient) GetSupportedVsCurrencies(ctx context.Context) ([]string, error) {
+	url := fmt.Sprintf("%s/simple/supported_vs_currencies", c.baseURL)
+
+	logger.GetLogger().WithField("url", c.redact(url)).Info("Fetching supported vs currencies from CoinGecko API")
+
+	var codes []string
+	if err := c.getJSON(ctx, url, &codes); err != nil {
+		return nil, fmt.Errorf("failed to fetch supported vs currencies: %w", err)
+	}
+	return codes, nil
+}
+
+// CoinListResponse is one entry of /coins/list; Platforms maps asset platform IDs to contract addresses
+type CoinListResponse struct {
+	ID        string             `json:"id"`
+	Symbol    string             `json:"symbol"`
+	Name      string             `json:"name"`
+	Platforms map[string]*string `json:"platforms"`
+}
+
+// Coin list statuses accepted by /coins/list
+const (
+	CoinStatusActive   = "active"
+	CoinStatusInactive = "inactive"
+)
+
+// GetCoinList fetches every coin CoinGecko lists with the given status, including coins without market data,
+// together with their contract addresses (/coins/list?include_platform=true)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/coins-list
+func (c *CoinGeckoClient) GetCoinList(ctx context.Context, status string) ([]CoinListResponse, error) {
+	reqURL := fmt.Sprintf("%s/coins/list?include_platform=true&status=%s", c.baseURL, status)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":    c.redact(reqURL),
+		"status": status,
+	}).Info("Fetching coin list from CoinGecko API")
+
+	var coins []CoinListResponse
+	if err := c.getJSON(ctx, reqURL, &coins); err != nil {
+		return nil, fmt.Errorf("failed to fetch coin list: %w", err)
+	}
+	return coins, nil
+}
+
+// GetCoinByContract fetches the coin deployed at a contract address on an asset platform (/coins/{id}/contract/{contract_address}).
+// The payload has the same shape as /coins/{id}; ErrNotFound means CoinGecko does not list the contract.
+// Reference: https://docs.coingecko.com/v3.0.1/reference/coins-contract-address
+func (c *CoinGeckoClient) GetCoinByContract(ctx context.Context, platformID, address string) (map[string]any, error) {
+	reqURL := fmt.Sprintf("%s/coins/%s/contract/%s", c.baseURL, url.PathEscape(platformID), url.PathEscape(address))
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":         c.redact(reqURL),
+		"platform_id": platformID,
+		"address":     address,
+	}).Info("Fetching coin by contract address from CoinGecko API")
+
 	var payload map[string]any
+	if err := c.getJSON(ctx, reqURL, &payload); err != nil {
+		return nil, fmt.Errorf("failed to fetch coin by contract address: %w", err)
+	}
+	return payload, nil
+}
 
-	for attempt := 0; attempt <= c.retryCount; attempt++ {
-		if attempt > 0 {
-			logger.GetLogger().WithField("attempt", attempt).Info("Retrying API request")
-			time.Sleep(c.retryDelay)
-		}
+// GetExchangeDetail fetches an exchange with its social links, centralized flag and top 100 tickers (/exchanges/{id})
+// Reference: https://docs.coingecko.com/v3.0.1/reference/exchanges-id
+func (c *CoinGeckoClient) GetExchangeDetail(ctx context.Context, exchangeID string) (map[string]any, error) {
+	reqURL := fmt.Sprintf("%s/exchanges/%s", c.baseURL, url.PathEscape(exchangeID))
 
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("User-Agent", "cgoffline/1.0")
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":         c.redact(reqURL),
+		"exchange_id": exchangeID,
+	}).Info("Fetching exchange detail from CoinGecko API")
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to execute request: %w", err)
-			continue
-		}
-		defer resp.Body.Close()
+	var payload map[string]any
+	if err := c.getJSON(ctx, reqURL, &payload); err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange detail: %w", err)
+	}
+	return payload, nil
+}
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			lastErr = fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-			continue
-		}
+// ExchangeTickersPerPage is the number of tickers /exchanges/{id}/tickers returns per page
+const ExchangeTickersPerPage = 100
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to read response body: %w", err)
-			continue
-		}
+// GetExchangeTickers fetches one page of an exchange's tickers (/exchanges/{id}/tickers), ordered by trust score
+// Reference: https://docs.coingecko.com/v3.0.1/reference/exchanges-id-tickers
+func (c *CoinGeckoClient) GetExchangeTickers(ctx context.Context, exchangeID string, page int) ([]TickerResponse, error) {
+	reqURL := fmt.Sprintf("%s/exchanges/%s/tickers?page=%d", c.baseURL, url.PathEscape(exchangeID), page)
 
-		if err := json.Unmarshal(body, &payload); err != nil {
-			lastErr = fmt.Errorf("failed to unmarshal response: %w", err)
-			continue
-		}
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":         c.redact(reqURL),
+		"exchange_id": exchangeID,
+		"page":        page,
+	}).Info("Fetching exchange tickers from CoinGecko API")
+
+	var response CoinTickersResponse
+	if err := c.getJSON(ctx, reqURL, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange tickers: %w", err)
+	}
+	return response.Tickers, nil
+}
 
-		return payload, nil
+// ExchangeVolumeChartResponse represents /exchanges/{id}/volume_chart: [unix milliseconds, volume in BTC] pairs.
+// CoinGecko sends the volume as a string, which json.Number accepts as well.
+type ExchangeVolumeChartResponse [][2]json.Number
+
+// GetExchangeVolumeChart fetches an exchange's 24h volume in BTC over the last given number of days (/exchanges/{id}/volume_chart).
+// days must be one of 1, 7, 14, 30, 90, 180 or 365; points are 10-minutely for 1 day, hourly up to 14 days and daily beyond.
+// Reference: https://docs.coingecko.com/v3.0.1/reference/exchanges-id-volume-chart
+func (c *CoinGeckoClient) GetExchangeVolumeChart(ctx context.Context, exchangeID string, days int) (ExchangeVolumeChartResponse, error) {
+	reqURL := fmt.Sprintf("%s/exchanges/%s/volume_chart?days=%d", c.baseURL, url.PathEscape(exchangeID), days)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":         c.redact(reqURL),
+		"exchange_id": exchangeID,
+		"days":        days,
+	}).Info("Fetching exchange volume chart from CoinGecko API")
+
+	var chart ExchangeVolumeChartResponse
+	if err := c.getJSON(ctx, reqURL, &chart); err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange volume chart: %w", err)
 	}
+	return chart, nil
+}
 
-	return nil, fmt.Errorf("failed to fetch coin tickers: %w", lastErr)
+// GlobalResponse represents the response structure for /global
+type GlobalResponse struct {
+	Data struct {
+		ActiveCryptocurrencies          *int               `json:"active_cryptocurrencies"`
+		Markets                         *int               `json:"markets"`
+		TotalMarketCap                  map[string]float64 `json:"total_market_cap"`
+		TotalVolume                     map[string]float64 `json:"total_volume"`
+		MarketCapPercentage             map[string]float64 `json:"market_cap_percentage"`
+		MarketCapChangePercentage24hUSD *float64           `json:"market_cap_change_percentage_24h_usd"`
+		UpdatedAt                       *int64             `json:"updated_at"` // unix seconds
+	} `json:"data"`
 }
 
-// fetchCoinMarketData performs the actual HTTP request for coin market data
-func (c *CoinGeckoClient) fetchCoinMarketData(ctx context.Context, url string, coinID string) ([]domain.CoinMarketData, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// GetGlobal fetches market-wide figures: total market cap and volume per currency, dominance and counts (/global)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/crypto-global
+func (c *CoinGeckoClient) GetGlobal(ctx context.Context) (*GlobalResponse, error) {
+	reqURL := fmt.Sprintf("%s/global", c.baseURL)
+
+	logger.GetLogger().WithField("url", c.redact(reqURL)).Info("Fetching global market data from CoinGecko API")
+
+	var global GlobalResponse
+	if err := c.getJSON(ctx, reqURL, &global); err != nil {
+		return nil, fmt.Errorf("failed to fetch global market data: %w", err)
 	}
+	return &global, nil
+}
 
-	// Set headers
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "cgoffline/1.0")
+// GlobalDefiResponse represents the response structure for /global/decentralized_finance_defi.
+// CoinGecko sends most figures as strings, which json.Number accepts as well.
+type GlobalDefiResponse struct {
+	Data struct {
+		DefiMarketCap        *json.Number `json:"defi_market_cap"`
+		EthMarketCap         *json.Number `json:"eth_market_cap"`
+		DefiToEthRatio       *json.Number `json:"defi_to_eth_ratio"`
+		TradingVolume24h     *json.Number `json:"trading_volume_24h"`
+		DefiDominance        *json.Number `json:"defi_dominance"`
+		TopCoinName          *string      `json:"top_coin_name"`
+		TopCoinDefiDominance *float64     `json:"top_coin_defi_dominance"`
+	} `json:"data"`
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+// GetGlobalDefi fetches market-wide DeFi figures (/global/decentralized_finance_defi)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/global-defi
+func (c *CoinGeckoClient) GetGlobalDefi(ctx context.Context) (*GlobalDefiResponse, error) {
+	reqURL := fmt.Sprintf("%s/global/decentralized_finance_defi", c.baseURL)
+
+	logger.GetLogger().WithField("url", c.redact(reqURL)).Info("Fetching global DeFi data from CoinGecko API")
+
+	var defi GlobalDefiResponse
+	if err := c.getJSON(ctx, reqURL, &defi); err != nil {
+		return nil, fmt.Errorf("failed to fetch global DeFi data: %w", err)
 	}
-	defer resp.Body.Close()
+	return &defi, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+// TrendingResponse represents the response structure for /search/trending
+type TrendingResponse struct {
+	Coins []struct {
+		Item struct {
+			ID            string   `json:"id"`
+			Name          string   `json:"name"`
+			Symbol        string   `json:"symbol"`
+			MarketCapRank *int     `json:"market_cap_rank"`
+			PriceBTC      *float64 `json:"price_btc"`
+		} `json:"item"`
+	} `json:"coins"`
+	NFTs []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Symbol string `json:"symbol"`
+	} `json:"nfts"`
+	Categories []struct {
+		Name string `json:"name"`
+		Slug string `json:"slug"`
+	} `json:"categories"`
+}
+
+// GetTrending fetches the coins, NFTs and categories trending on CoinGecko right now (/search/trending)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/trending-search
+func (c *CoinGeckoClient) GetTrending(ctx context.Context) (*TrendingResponse, error) {
+	reqURL := fmt.Sprintf("%s/search/trending", c.baseURL)
+
+	logger.GetLogger().WithField("url", c.redact(reqURL)).Info("Fetching trending search lists from CoinGecko API")
+
+	var trending TrendingResponse
+	if err := c.getJSON(ctx, reqURL, &trending); err != nil {
+		return nil, fmt.Errorf("failed to fetch trending search lists: %w", err)
 	}
+	return &trending, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+// SearchResponse represents the response structure for /search
+type SearchResponse struct {
+	Coins []struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		Symbol        string `json:"symbol"`
+		MarketCapRank *int   `json:"market_cap_rank"`
+	} `json:"coins"`
+	Exchanges []struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		MarketType string `json:"market_type"`
+	} `json:"exchanges"`
+	Categories []struct {
+		Name string `json:"name"`
+	} `json:"categories"`
+	NFTs []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Symbol string `json:"symbol"`
+	} `json:"nfts"`
+}
+
+// Search fetches the coins, exchanges, categories and NFTs matching a name or symbol (/search?query=)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/search-data
+func (c *CoinGeckoClient) Search(ctx context.Context, query string) (*SearchResponse, error) {
+	reqURL := fmt.Sprintf("%s/search?query=%s", c.baseURL, url.QueryEscape(query))
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":   c.redact(reqURL),
+		"query": query,
+	}).Info("Searching CoinGecko API")
+
+	var result SearchResponse
+	if err := c.getJSON(ctx, reqURL, &result); err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
 	}
+	return &result, nil
+}
 
-	var response struct {
-		Tickers []struct {
-			Base   string `json:"base"`
-			Target string `json:"target"`
-			Market struct {
-				Name       string `json:"name"`
-				Identifier string `json:"identifier"`
-			} `json:"market"`
-			Last          *float64 `json:"last"`
-			Volume        *float64 `json:"volume"`
-			ConvertedLast struct {
-				USD *float64 `json:"usd"`
-			} `json:"converted_last"`
-			ConvertedVolume struct {
-				USD *float64 `json:"usd"`
-			} `json:"converted_volume"`
-			TrustScore             string     `json:"trust_score"`
-			BidAskSpreadPercentage *float64   `json:"bid_ask_spread_percentage"`
-			Timestamp              *time.Time `json:"timestamp"`
-			LastTradedAt           *time.Time `json:"last_traded_at"`
-			LastFetchAt            *time.Time `json:"last_fetch_at"`
-			IsAnomaly              bool       `json:"is_anomaly"`
-			IsStale                bool       `json:"is_stale"`
-			TradeURL               *string    `json:"trade_url"`
-			TokenInfoURL           *string    `json:"token_info_url"`
-			CoinID                 string     `json:"coin_id"`
-		} `json:"tickers"`
-	}
-
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+// TopGainersLosersResponse represents the response structure for /coins/top_gainers_losers.
+// Figures are keyed after the requested currency, e.g. "usd", "usd_24h_vol" and "usd_24h_change".
+type TopGainersLosersResponse struct {
+	TopGainers []map[string]any `json:"top_gainers"`
+	TopLosers  []map[string]any `json:"top_losers"`
+}
+
+// GetTopGainersLosers fetches the 30 coins with the largest 24h price gains and losses among the top 1000 by
+// market cap (/coins/top_gainers_losers). Only served on the pro plan.
+// Reference: https://docs.coingecko.com/reference/coins-top-gainers-losers
+func (c *CoinGeckoClient) GetTopGainersLosers(ctx context.Context, vsCurrency string) (*TopGainersLosersResponse, error) {
+	reqURL := fmt.Sprintf("%s/coins/top_gainers_losers?vs_currency=%s&duration=24h", c.baseURL, url.QueryEscape(vsCurrency))
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":         c.redact(reqURL),
+		"vs_currency": vsCurrency,
+	}).Info("Fetching top gainers and losers from CoinGecko API")
+
+	var movers TopGainersLosersResponse
+	if err := c.getJSON(ctx, reqURL, &movers); err != nil {
+		return nil, fmt.Errorf("failed to fetch top gainers and losers: %w", err)
 	}
+	return &movers, nil
+}
 
-	// Convert API response to domain models
-	marketData := make([]domain.CoinMarketData, 0, len(response.Tickers))
-	for _, ticker := range response.Tickers {
-		// Skip if no price data
-		if ticker.Last == nil {
-			continue
-		}
+// NFTsPerPage is the largest page /nfts/list serves
+const NFTsPerPage = 250
 
-		marketData = append(marketData, domain.CoinMarketData{
-			// Note: CoinID and ExchangeID will be set by the service layer
-			// after resolving the coin and exchange IDs
-			Price:            ticker.Last,
-			Volume24h:        ticker.Volume,
-			VolumePercentage: nil, // Not available in this API response
-			LastUpdated:      ticker.LastTradedAt,
-		})
+// NFTListResponse represents one entry of /nfts/list
+type NFTListResponse struct {
+	ID              string  `json:"id"`
+	ContractAddress *string `json:"contract_address"`
+	Name            string  `json:"name"`
+	AssetPlatformID *string `json:"asset_platform_id"`
+	Symbol          *string `json:"symbol"`
+}
+
+// GetNFTList fetches a page of the NFT collections CoinGecko lists (/nfts/list)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/nfts-list
+func (c *CoinGeckoClient) GetNFTList(ctx context.Context, page int) ([]NFTListResponse, error) {
+	reqURL := fmt.Sprintf("%s/nfts/list?per_page=%d&page=%d", c.baseURL, NFTsPerPage, page)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":  c.redact(reqURL),
+		"page": page,
+	}).Info("Fetching NFT list from CoinGecko API")
+
+	var list []NFTListResponse
+	if err := c.getJSON(ctx, reqURL, &list); err != nil {
+		return nil, fmt.Errorf("failed to fetch NFT list: %w", err)
 	}
+	return list, nil
+}
+
+// GetNFT fetches an NFT collection with its floor price, market cap, volume and links (/nfts/{id})
+// Reference: https://docs.coingecko.com/v3.0.1/reference/nfts-id
+func (c *CoinGeckoClient) GetNFT(ctx context.Context, nftID string) (map[string]any, error) {
+	reqURL := fmt.Sprintf("%s/nfts/%s", c.baseURL, url.PathEscape(nftID))
 
-	return marketData, nil
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":    c.redact(reqURL),
+		"nft_id": nftID,
+	}).Info("Fetching NFT collection from CoinGecko API")
+
+	var payload map[string]any
+	if err := c.getJSON(ctx, reqURL, &payload); err != nil {
+		return nil, fmt.Errorf("failed to fetch NFT collection: %w", err)
+	}
+	return payload, nil
 }
 
-// HealthCheck checks if the CoinGecko API is accessible
-func (c *CoinGeckoClient) HealthCheck(ctx context.Context) error {
-	url := fmt.Sprintf("%s/ping", c.baseURL)
+// GetNFTByContract fetches the NFT collection deployed at a contract address on an asset platform
+// (/nfts/{asset_platform_id}/contract/{contract_address}). The payload has the same shape as /nfts/{id};
+// ErrNotFound means CoinGecko does not list the contract.
+// Reference: https://docs.coingecko.com/v3.0.1/reference/nfts-contract-address
+func (c *CoinGeckoClient) GetNFTByContract(ctx context.Context, platformID, address string) (map[string]any, error) {
+	reqURL := fmt.Sprintf("%s/nfts/%s/contract/%s", c.baseURL, url.PathEscape(platformID), url.PathEscape(address))
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create health check request: %w", err)
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":         c.redact(reqURL),
+		"platform_id": platformID,
+		"address":     address,
+	}).Info("Fetching NFT collection by contract address from CoinGecko API")
+
+	var payload map[string]any
+	if err := c.getJSON(ctx, reqURL, &payload); err != nil {
+		return nil, fmt.Errorf("failed to fetch NFT collection by contract address: %w", err)
 	}
+	return payload, nil
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("health check failed: %w", err)
+// DerivativeResponse represents one contract of /derivatives. Price is sent as a string.
+type DerivativeResponse struct {
+	Market                   string   `json:"market"`
+	Symbol                   string   `json:"symbol"`
+	IndexID                  string   `json:"index_id"`
+	Price                    string   `json:"price"`
+	PricePercentageChange24h *float64 `json:"price_percentage_change_24h"`
+	ContractType             string   `json:"contract_type"`
+	Index                    *float64 `json:"index"`
+	Basis                    *float64 `json:"basis"`
+	Spread                   *float64 `json:"spread"`
+	FundingRate              *float64 `json:"funding_rate"`
+	OpenInterest             *float64 `json:"open_interest"`
+	Volume24h                *float64 `json:"volume_24h"`
+	LastTradedAt             *int64   `json:"last_traded_at"`
+	ExpiredAt                *int64   `json:"expired_at"`
+}
+
+// GetDerivatives fetches every derivative contract ticker CoinGecko tracks (/derivatives)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/derivatives-tickers
+func (c *CoinGeckoClient) GetDerivatives(ctx context.Context) ([]DerivativeResponse, error) {
+	reqURL := fmt.Sprintf("%s/derivatives", c.baseURL)
+
+	logger.GetLogger().WithField("url", c.redact(reqURL)).Info("Fetching derivatives from CoinGecko API")
+
+	var derivatives []DerivativeResponse
+	if err := c.getJSON(ctx, reqURL, &derivatives); err != nil {
+		return nil, fmt.Errorf("failed to fetch derivatives: %w", err)
 	}
-	defer resp.Body.Close()
+	return derivatives, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("health check failed with status %d", resp.StatusCode)
+// DerivativesExchangesPerPage is the page size used for /derivatives/exchanges
+const DerivativesExchangesPerPage = 100
+
+// DerivativesExchangeResponse represents one entry of /derivatives/exchanges. TradeVolume24hBTC is sent as a string.
+type DerivativesExchangeResponse struct {
+	ID                     string   `json:"id"`
+	Name                   string   `json:"name"`
+	OpenInterestBTC        *float64 `json:"open_interest_btc"`
+	TradeVolume24hBTC      string   `json:"trade_volume_24h_btc"`
+	NumberOfPerpetualPairs *int     `json:"number_of_perpetual_pairs"`
+	NumberOfFuturesPairs   *int     `json:"number_of_futures_pairs"`
+	Image                  *string  `json:"image"`
+	YearEstablished        *int     `json:"year_established"`
+	Country                *string  `json:"country"`
+	Description            *string  `json:"description"`
+	URL                    *string  `json:"url"`
+}
+
+// GetDerivativesExchanges fetches a page of derivatives exchanges, largest open interest first (/derivatives/exchanges)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/derivatives-exchanges
+func (c *CoinGeckoClient) GetDerivativesExchanges(ctx context.Context, page int) ([]DerivativesExchangeResponse, error) {
+	reqURL := fmt.Sprintf("%s/derivatives/exchanges?order=open_interest_btc_desc&per_page=%d&page=%d",
+		c.baseURL, DerivativesExchangesPerPage, page)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"url":  c.redact(reqURL),
+		"page": page,
+	}).Info("Fetching derivatives exchanges from CoinGecko API")
+
+	var exchanges []DerivativesExchangeResponse
+	if err := c.getJSON(ctx, reqURL, &exchanges); err != nil {
+		return nil, fmt.Errorf("failed to fetch derivatives exchanges: %w", err)
 	}
+	return exchanges, nil
+}
 
-	return nil
+// ExchangeRateResponse represents one unit of /exchange_rates; Value is how many of the unit one BTC is worth
+type ExchangeRateResponse struct {
+	Name  string  `json:"name"`
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+	Type  string  `json:"type"`
+}
+
+// ExchangeRatesResponse represents the response of /exchange_rates, keyed by currency
+type ExchangeRatesResponse struct {
+	Rates map[string]ExchangeRateResponse `json:"rates"`
+}
+
+// GetExchangeRates fetches the BTC exchange rates of fiat currencies, cryptocurrencies and commodities (/exchange_rates)
+// Reference: https://docs.coingecko.com/v3.0.1/reference/exchange-rates
+func (c *CoinGeckoClient) GetExchangeRates(ctx context.Context) (*ExchangeRatesResponse, error) {
+	reqURL := fmt.Sprintf("%s/exchange_rates", c.baseURL)
+
+	logger.GetLogger().WithField("url", c.redact(reqURL)).Info("Fetching exchange rates from CoinGecko API")
+
+	var rates ExchangeRatesResponse
+	if err := c.getJSON(ctx, reqURL, &rates); err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+	return &rates, nil
 }
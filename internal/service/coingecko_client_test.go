@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cgoffline/pkg/config"
+)
+
+// newTestClient returns a client that sends every request to a test server answering with body
+func newTestClient(t *testing.T, body string) *CoinGeckoClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return NewCoinGeckoClient(config.APIConfig{CoinGeckoBaseURL: srv.URL, Timeout: time.Second})
+}
+
+func TestGetCoinTickers(t *testing.T) {
+	body := `{"name":"Bitcoin","tickers":[{"base":"BTC","target":"USDT","market":{"name":"Binance","identifier":"binance"},"last":67000.5,"trust_score":"green","is_anomaly":false,"is_stale":true}]}`
+	client := newTestClient(t, body)
+
+	response, raw, err := client.GetCoinTickers(context.Background(), "bitcoin", 1)
+	if err != nil {
+		t.Fatalf("GetCoinTickers() error = %v", err)
+	}
+	if string(raw) != body {
+		t.Errorf("raw page = %s, want the body as sent", raw)
+	}
+	if response.Name != "Bitcoin" || len(response.Tickers) != 1 {
+		t.Fatalf("GetCoinTickers() = %+v, want one Bitcoin ticker", response)
+	}
+	ticker := response.Tickers[0]
+	if ticker.Base != "BTC" || ticker.Target != "USDT" || ticker.Market.Identifier != "binance" || !ticker.IsStale {
+		t.Errorf("ticker = %+v", ticker)
+	}
+}
+
+func TestGetAssetPlatforms(t *testing.T) {
+	body := `[{"id":"arbitrum-one","chain_identifier":42161,"name":"Arbitrum One","shortname":"Arbitrum","native_coin_id":"ethereum","image":{"thumb":"https://coin-images.coingecko.com/asset_platforms/images/33/thumb/AO_logomark.png"}},{"id":"sora","chain_identifier":null,"name":"Sora","shortname":"","native_coin_id":"sora"}]`
+	client := newTestClient(t, body)
+
+	platforms, err := client.GetAssetPlatforms(context.Background())
+	if err != nil {
+		t.Fatalf("GetAssetPlatforms() error = %v", err)
+	}
+	if len(platforms) != 2 {
+		t.Fatalf("GetAssetPlatforms() returned %d platforms, want 2", len(platforms))
+	}
+	arbitrum := platforms[0]
+	if arbitrum.ID != "arbitrum-one" || arbitrum.ChainIdentifier == nil || *arbitrum.ChainIdentifier != 42161 {
+		t.Errorf("platform = %+v", arbitrum)
+	}
+	if arbitrum.ShortName == nil || *arbitrum.ShortName != "Arbitrum" {
+		t.Errorf("short name = %v, want Arbitrum", arbitrum.ShortName)
+	}
+	if platforms[1].ChainIdentifier != nil {
+		t.Errorf("sora chain identifier = %d, want nil", *platforms[1].ChainIdentifier)
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Where a contract resolution came from
+const (
+	ContractSourceContracts = "coin_contracts" // extracted contracts of synced coins
+	ContractSourceDetails   = "coin_details"   // platforms of stored /coins/{id} payloads
+	ContractSourceCache     = "cache"          // an earlier API lookup
+	ContractSourceAPI       = "api"            // CoinGecko, asked just now
+)
+
+// ErrInvalidContract is returned when the asset platform or the contract address is missing
+var ErrInvalidContract = errors.New("asset platform and contract address are required")
+
+// ContractResolution is the coin behind a contract address.
+// CoingeckoID is nil when the contract is unknown; Source is empty if it could not be asked about online.
+type ContractResolution struct {
+	AssetPlatformID string  `json:"asset_platform_id"`
+	ContractAddress string  `json:"contract_address"`
+	CoingeckoID     *string `json:"coingecko_id"`
+	Source          string  `json:"source,omitempty"`
+}
+
+// ContractOptions controls when ResolveContract falls back to the API
+type ContractOptions struct {
+	Online  bool          // ask CoinGecko about contracts unknown locally
+	MissTTL time.Duration // how long a contract CoinGecko did not list is not asked about again
+}
+
+// ContractService defines the interface for contract address lookups
+type ContractService interface {
+	ResolveContract(ctx context.Context, platformID, address string) (*ContractResolution, error)
+}
+
+type contractService struct {
+	coinRepo        repository.CoinRepository
+	contractRepo    repository.CoinContractRepository
+	coinDetailRepo  repository.CoinDetailRepository
+	lookupRepo      repository.ContractLookupRepository
+	coingeckoClient *CoinGeckoClient
+	opts            ContractOptions
+}
+
+// NewContractService creates a new instance of ContractService
+func NewContractService(
+	coinRepo repository.CoinRepository,
+	contractRepo repository.CoinContractRepository,
+	coinDetailRepo repository.CoinDetailRepository,
+	lookupRepo repository.ContractLookupRepository,
+	client *CoinGeckoClient,
+	opts ContractOptions,
+) ContractService {
+	return &contractService{
+		coinRepo:        coinRepo,
+		contractRepo:    contractRepo,
+		coinDetailRepo:  coinDetailRepo,
+		lookupRepo:      lookupRepo,
+		coingeckoClient: client,
+		opts:            opts,
+	}
+}
+
+// ResolveContract finds the coin deployed at a contract address. Local data is searched first: extracted contracts,
+// then the raw payloads, then earlier API answers. Only when all of them miss, and lookups are online, is CoinGecko
+// asked; its answer is cached, and a found coin that is synced gets its contracts stored for the next lookup.
+func (s *contractService) ResolveContract(ctx context.Context, platformID, address string) (*ContractResolution, error) {
+	platformID = strings.TrimSpace(platformID)
+	address = domain.NormalizeContractAddress(address)
+	if platformID == "" || address == "" {
+		return nil, ErrInvalidContract
+	}
+	resolution := &ContractResolution{AssetPlatformID: platformID, ContractAddress: address}
+
+	coin, err := s.contractRepo.GetCoinByAddress(platformID, address)
+	if err != nil {
+		return nil, err
+	}
+	if coin != nil {
+		resolution.CoingeckoID, resolution.Source = &coin.CoingeckoID, ContractSourceContracts
+		return resolution, nil
+	}
+
+	id, err := s.coinDetailRepo.GetCoingeckoIDByContract(platformID, address)
+	if err != nil {
+		return nil, err
+	}
+	if id != "" {
+		resolution.CoingeckoID, resolution.Source = &id, ContractSourceDetails
+		return resolution, nil
+	}
+
+	cached, err := s.lookupRepo.Get(platformID, address)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && (cached.CoingeckoID != nil || time.Since(cached.CheckedAt) < s.opts.MissTTL || !s.opts.Online) {
+		resolution.CoingeckoID, resolution.Source = cached.CoingeckoID, ContractSourceCache
+		return resolution, nil
+	}
+	if !s.opts.Online {
+		return resolution, nil
+	}
+
+	payload, err := s.coingeckoClient.GetCoinByContract(ctx, platformID, address)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	lookup := domain.ContractLookup{AssetPlatformID: platformID, ContractAddress: address, CheckedAt: time.Now().UTC()}
+	if id, ok := payload["id"].(string); ok && id != "" {
+		lookup.CoingeckoID = &id
+		s.storeContracts(id, payload)
+	}
+	if err := s.lookupRepo.Save(lookup); err != nil {
+		logger.GetLogger().WithError(err).WithFields(map[string]interface{}{
+			"platform_id": platformID,
+			"address":     address,
+		}).Warn("Failed to cache contract lookup")
+	}
+
+	resolution.CoingeckoID, resolution.Source = lookup.CoingeckoID, ContractSourceAPI
+	return resolution, nil
+}
+
+// storeContracts records the contracts of a coin found through the API, provided the coin is synced
+func (s *contractService) storeContracts(coingeckoID string, payload map[string]any) {
+	coin, err := s.coinRepo.GetByCoingeckoID(coingeckoID)
+	if err != nil || coin == nil {
+		return
+	}
+	if err := s.contractRepo.ReplaceForCoin(coin.ID, coinContracts(payload)); err != nil {
+		logger.GetLogger().WithError(err).WithField("coin_id", coingeckoID).Warn("Failed to store coin contracts")
+	}
+}
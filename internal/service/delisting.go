@@ -0,0 +1,45 @@
+package service
+
+import (
+	"cgoffline/pkg/logger"
+)
+
+// delistMinRatio is the share of the currently stored records a full sync must return before the missing ones are
+// marked delisted. A smaller answer is more likely a truncated or broken API response than a mass delisting.
+const delistMinRatio = 0.9
+
+// markDelisted soft-deletes the records of kind a full sync did not return, given the IDs it did return and the
+// number of records stored before it ran, and returns how many were marked. Reconciliation is skipped when the API
+// returned suspiciously few records.
+func markDelisted(kind string, listed []string, stored int, mark func(listed []string) (int64, error)) (int64, error) {
+	unique := make(map[string]bool, len(listed))
+	ids := make([]string, 0, len(listed))
+	for _, id := range listed {
+		if id != "" && !unique[id] {
+			unique[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if float64(len(ids)) < delistMinRatio*float64(stored) {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"kind":      kind,
+			"returned":  len(ids),
+			"stored":    stored,
+			"min_ratio": delistMinRatio,
+		}).Warn("API returned suspiciously few records; skipping delisting")
+		return 0, nil
+	}
+
+	n, err := mark(ids)
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"kind":  kind,
+			"count": n,
+		}).Info("Marked records missing from the API as delisted")
+	}
+	return n, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMarkDelisted(t *testing.T) {
+	tests := []struct {
+		name     string
+		listed   []string
+		stored   int
+		wantIDs  []string // nil when mark must not be called
+		wantMark int64
+	}{
+		{
+			name:     "nothing stored yet",
+			listed:   []string{"a", "b"},
+			stored:   0,
+			wantIDs:  []string{"a", "b"},
+			wantMark: 0,
+		},
+		{
+			name:     "empty answer with nothing stored",
+			listed:   nil,
+			stored:   0,
+			wantIDs:  []string{},
+			wantMark: 0,
+		},
+		{
+			name:   "empty answer with records stored",
+			listed: nil,
+			stored: 10,
+		},
+		{
+			name:     "exactly the minimum ratio",
+			listed:   []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"},
+			stored:   10,
+			wantIDs:  []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"},
+			wantMark: 1,
+		},
+		{
+			name:   "just below the minimum ratio",
+			listed: []string{"a", "b", "c", "d", "e", "f", "g", "h"},
+			stored: 10,
+		},
+		{
+			name:   "duplicates do not count towards the ratio",
+			listed: []string{"a", "b", "c", "d", "e", "f", "g", "h", "h"},
+			stored: 10,
+		},
+		{
+			name:   "empty IDs do not count towards the ratio",
+			listed: []string{"a", "b", "c", "d", "e", "f", "g", "h", ""},
+			stored: 10,
+		},
+		{
+			name:     "duplicates and empty IDs are dropped",
+			listed:   []string{"a", "", "b", "a", "c", ""},
+			stored:   3,
+			wantIDs:  []string{"a", "b", "c"},
+			wantMark: 0,
+		},
+		{
+			name:     "more returned than stored",
+			listed:   []string{"a", "b", "c"},
+			stored:   2,
+			wantIDs:  []string{"a", "b", "c"},
+			wantMark: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIDs []string
+			called := false
+			n, err := markDelisted("test", tt.listed, tt.stored, func(listed []string) (int64, error) {
+				called = true
+				gotIDs = listed
+				return tt.wantMark, nil
+			})
+			if err != nil {
+				t.Fatalf("markDelisted() error = %v", err)
+			}
+			if called != (tt.wantIDs != nil) {
+				t.Fatalf("mark called = %v, want %v", called, tt.wantIDs != nil)
+			}
+			if called && !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("mark got %v, want %v", gotIDs, tt.wantIDs)
+			}
+			if n != tt.wantMark {
+				t.Errorf("markDelisted() = %d, want %d", n, tt.wantMark)
+			}
+		})
+	}
+}
+
+func TestMarkDelistedError(t *testing.T) {
+	want := errors.New("boom")
+	n, err := markDelisted("test", []string{"a"}, 1, func([]string) (int64, error) { return 3, want })
+	if !errors.Is(err, want) {
+		t.Fatalf("markDelisted() error = %v, want %v", err, want)
+	}
+	if n != 0 {
+		t.Errorf("markDelisted() = %d, want 0 on error", n)
+	}
+}
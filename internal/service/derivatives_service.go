@@ -0,0 +1,271 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DerivativesService defines the interface for derivatives exchange and contract operations
+type DerivativesService interface {
+	SyncDerivativesExchanges(ctx context.Context) error
+	SyncDerivatives(ctx context.Context) error
+}
+
+type derivativesService struct {
+	exchangeRepo    repository.DerivativesExchangeRepository
+	contractRepo    repository.DerivativeContractRepository
+	snapshotRepo    repository.DerivativeSnapshotRepository
+	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
+}
+
+// NewDerivativesService creates a new instance of DerivativesService
+func NewDerivativesService(
+	exchangeRepo repository.DerivativesExchangeRepository,
+	contractRepo repository.DerivativeContractRepository,
+	snapshotRepo repository.DerivativeSnapshotRepository,
+	client *CoinGeckoClient,
+	journal *SyncJournal,
+) DerivativesService {
+	return &derivativesService{
+		exchangeRepo:    exchangeRepo,
+		contractRepo:    contractRepo,
+		snapshotRepo:    snapshotRepo,
+		coingeckoClient: client,
+		journal:         journal,
+	}
+}
+
+// SyncDerivativesExchanges pages through /derivatives/exchanges and stores every exchange in derivatives_exchanges.
+// Exchanges no longer listed are marked delisted.
+func (s *derivativesService) SyncDerivativesExchanges(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindDerivativesExchanges)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting derivatives exchanges synchronization")
+
+	current, err := s.exchangeRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	var exchanges []domain.DerivativesExchange
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		list, err := s.coingeckoClient.GetDerivativesExchanges(ctx, page)
+		if err != nil {
+			return err
+		}
+		for _, e := range list {
+			if e.ID == "" || seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			exchanges = append(exchanges, derivativesExchange(e))
+		}
+		if len(list) < DerivativesExchangesPerPage {
+			break
+		}
+	}
+
+	if err := s.exchangeRepo.UpsertBatch(exchanges); err != nil {
+		return err
+	}
+	run.addWritten(len(exchanges))
+
+	listed := make([]string, len(exchanges))
+	for i, e := range exchanges {
+		listed[i] = e.CoingeckoID
+	}
+	delisted, err := markDelisted(domain.SyncKindDerivativesExchanges, listed, len(current), s.exchangeRepo.MarkDelisted)
+	if err != nil {
+		return fmt.Errorf("failed to mark delisted derivatives exchanges: %w", err)
+	}
+
+	updated, err := s.exchangeRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	run.addInserted(len(updated) - len(current) + int(delisted))
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"exchanges": len(exchanges),
+		"delisted":  delisted,
+	}).Info("Derivatives exchanges synchronization completed")
+	return nil
+}
+
+// SyncDerivatives fetches /derivatives, stores the latest state of every contract in derivative_contracts and
+// appends a snapshot of each to derivative_snapshots. Contracts are linked to the derivatives exchange whose name
+// matches their market, so derivatives exchanges should be synced first. Contracts no longer listed, such as
+// expired futures, are marked delisted.
+func (s *derivativesService) SyncDerivatives(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindDerivatives)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting derivatives synchronization")
+	syncStart := time.Now()
+	capturedAt := syncStart.UTC().Truncate(time.Second)
+
+	current, err := s.contractRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	exchanges, err := s.exchangeRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	exchangeIDs := make(map[string]uint, len(exchanges))
+	for _, e := range exchanges {
+		exchangeIDs[strings.ToLower(e.Name)] = e.ID
+	}
+
+	tickers, err := s.coingeckoClient.GetDerivatives(ctx)
+	if err != nil {
+		return err
+	}
+
+	contracts := make([]domain.DerivativeContract, 0, len(tickers))
+	keys := make([]string, 0, len(tickers))
+	seen := make(map[string]bool, len(tickers))
+	unmatched := make(map[string]bool)
+	for _, t := range tickers {
+		key := derivativeKey(t.Market, t.Symbol)
+		if t.Market == "" || t.Symbol == "" || seen[key] {
+			run.addSkipped(1)
+			continue
+		}
+		seen[key] = true
+
+		contract := derivativeContract(t)
+		if id, ok := exchangeIDs[strings.ToLower(t.Market)]; ok {
+			contract.DerivativesExchangeID = &id
+		} else {
+			unmatched[t.Market] = true
+		}
+		contracts = append(contracts, contract)
+		keys = append(keys, key)
+	}
+
+	if err := s.contractRepo.UpsertBatch(contracts); err != nil {
+		return err
+	}
+	run.addWritten(len(contracts))
+
+	// Every listed contract was just touched, so the ones left behind are those /derivatives no longer returns
+	delisted, err := markDelisted(domain.SyncKindDerivatives, keys, len(current), func([]string) (int64, error) {
+		return s.contractRepo.MarkDelisted(syncStart)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark delisted derivative contracts: %w", err)
+	}
+
+	updated, err := s.contractRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	run.addInserted(len(updated) - len(current) + int(delisted))
+
+	snapshots := make([]domain.DerivativeSnapshot, 0, len(updated))
+	for _, c := range updated {
+		if seen[derivativeKey(c.Market, c.Symbol)] {
+			snapshots = append(snapshots, derivativeSnapshot(c, capturedAt))
+		}
+	}
+	if err := s.snapshotRepo.CreateBatch(snapshots); err != nil {
+		return err
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"contracts":         len(contracts),
+		"snapshots":         len(snapshots),
+		"delisted":          delisted,
+		"unmatched_markets": len(unmatched),
+	}).Info("Derivatives synchronization completed")
+	return nil
+}
+
+// derivativesExchange converts a /derivatives/exchanges entry into a derivatives exchange
+func derivativesExchange(e DerivativesExchangeResponse) domain.DerivativesExchange {
+	return domain.DerivativesExchange{
+		CoingeckoID:            e.ID,
+		Name:                   e.Name,
+		OpenInterestBTC:        e.OpenInterestBTC,
+		TradeVolume24hBTC:      parseOptionalFloat(e.TradeVolume24hBTC),
+		NumberOfPerpetualPairs: e.NumberOfPerpetualPairs,
+		NumberOfFuturesPairs:   e.NumberOfFuturesPairs,
+		YearEstablished:        e.YearEstablished,
+		Country:                e.Country,
+		Description:            e.Description,
+		URL:                    e.URL,
+		Image:                  e.Image,
+	}
+}
+
+// derivativeContract converts a /derivatives ticker into a derivative contract
+func derivativeContract(t DerivativeResponse) domain.DerivativeContract {
+	contract := domain.DerivativeContract{
+		Market:                   t.Market,
+		Symbol:                   t.Symbol,
+		ContractType:             t.ContractType,
+		Price:                    parseOptionalFloat(t.Price),
+		PricePercentageChange24h: t.PricePercentageChange24h,
+		Index:                    t.Index,
+		Basis:                    t.Basis,
+		Spread:                   t.Spread,
+		FundingRate:              t.FundingRate,
+		OpenInterestUSD:          t.OpenInterest,
+		Volume24hUSD:             t.Volume24h,
+		LastTradedAt:             unixTime(t.LastTradedAt),
+		ExpiredAt:                unixTime(t.ExpiredAt),
+	}
+	if t.IndexID != "" {
+		indexID := t.IndexID
+		contract.IndexID = &indexID
+	}
+	return contract
+}
+
+// derivativeSnapshot copies the figures of a stored derivative contract into a snapshot captured at the given time
+func derivativeSnapshot(c domain.DerivativeContract, capturedAt time.Time) domain.DerivativeSnapshot {
+	return domain.DerivativeSnapshot{
+		ContractID:      c.ID,
+		CapturedAt:      capturedAt,
+		Price:           c.Price,
+		Index:           c.Index,
+		Basis:           c.Basis,
+		Spread:          c.Spread,
+		FundingRate:     c.FundingRate,
+		OpenInterestUSD: c.OpenInterestUSD,
+		Volume24hUSD:    c.Volume24hUSD,
+	}
+}
+
+// derivativeKey identifies a derivative contract, whose symbol is only unique within its market
+func derivativeKey(market, symbol string) string {
+	return market + "\x00" + symbol
+}
+
+// parseOptionalFloat parses a number sent as a string, returning nil when it is empty or malformed
+func parseOptionalFloat(s string) *float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// unixTime converts optional unix seconds into a UTC time, treating 0 as missing
+func unixTime(seconds *int64) *time.Time {
+	if seconds == nil || *seconds == 0 {
+		return nil
+	}
+	t := time.Unix(*seconds, 0).UTC()
+	return &t
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoExchangeRate is returned when no stored exchange rate can convert between two units
+var ErrNoExchangeRate = errors.New("no exchange rate")
+
+// Conversion is the result of converting an amount with the exchange rates captured at RatesCapturedAt
+type Conversion struct {
+	Amount          float64   `json:"amount"`
+	From            string    `json:"from"`
+	To              string    `json:"to"`
+	Value           float64   `json:"value"`
+	Rate            float64   `json:"rate"` // units of To per unit of From
+	RatesCapturedAt time.Time `json:"rates_captured_at"`
+}
+
+// CurrencyConverter converts amounts between the units of one exchange rate snapshot.
+// It is meant for valuing many stored USD figures, such as a price history, without a lookup per value.
+type CurrencyConverter struct {
+	capturedAt time.Time
+	rates      map[string]float64 // units per BTC, keyed by lower-case currency
+}
+
+// CapturedAt returns when the snapshot the converter uses was captured
+func (c *CurrencyConverter) CapturedAt() time.Time {
+	return c.capturedAt
+}
+
+// Rate returns how many units of to one unit of from is worth
+func (c *CurrencyConverter) Rate(from, to string) (float64, error) {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	fromRate, ok := c.rates[from]
+	if !ok || fromRate == 0 {
+		return 0, fmt.Errorf("%w for %s", ErrNoExchangeRate, from)
+	}
+	toRate, ok := c.rates[to]
+	if !ok {
+		return 0, fmt.Errorf("%w for %s", ErrNoExchangeRate, to)
+	}
+	return toRate / fromRate, nil
+}
+
+// Convert converts an amount of from into to
+func (c *CurrencyConverter) Convert(amount float64, from, to string) (float64, error) {
+	rate, err := c.Rate(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// ConvertUSD converts a USD amount into to
+func (c *CurrencyConverter) ConvertUSD(amount float64, to string) (float64, error) {
+	return c.Convert(amount, "usd", to)
+}
+
+// ExchangeRateService defines the interface for exchange rate snapshots and currency conversion
+type ExchangeRateService interface {
+	SyncExchangeRates(ctx context.Context) error
+	Converter(at time.Time) (*CurrencyConverter, error)
+	ConvertUSD(amount float64, to string, at time.Time) (*Conversion, error)
+}
+
+type exchangeRateService struct {
+	repo            repository.ExchangeRateRepository
+	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
+}
+
+// NewExchangeRateService creates a new instance of ExchangeRateService
+func NewExchangeRateService(repo repository.ExchangeRateRepository, client *CoinGeckoClient, journal *SyncJournal) ExchangeRateService {
+	return &exchangeRateService{
+		repo:            repo,
+		coingeckoClient: client,
+		journal:         journal,
+	}
+}
+
+// SyncExchangeRates fetches /exchange_rates and appends a snapshot of every rate to exchange_rates
+func (s *exchangeRateService) SyncExchangeRates(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindExchangeRates)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting exchange rates synchronization")
+	capturedAt := time.Now().UTC().Truncate(time.Second)
+
+	response, err := s.coingeckoClient.GetExchangeRates(ctx)
+	if err != nil {
+		return err
+	}
+
+	rates := exchangeRates(response, capturedAt)
+	if len(rates) == 0 {
+		return fmt.Errorf("exchange rates response without rates")
+	}
+	if err := s.repo.CreateBatch(rates); err != nil {
+		return err
+	}
+	run.addWritten(len(rates))
+	run.addInserted(len(rates))
+
+	logger.GetLogger().WithField("rates", len(rates)).Info("Exchange rates synchronization completed")
+	return nil
+}
+
+// Converter returns a converter using the last exchange rate snapshot captured at or before the given time.
+// ErrNoExchangeRate is returned when no snapshot was captured by then.
+func (s *exchangeRateService) Converter(at time.Time) (*CurrencyConverter, error) {
+	rates, err := s.repo.GetAt(at)
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w captured at or before %s", ErrNoExchangeRate, at.UTC().Format(time.RFC3339))
+	}
+
+	converter := &CurrencyConverter{capturedAt: rates[0].CapturedAt, rates: make(map[string]float64, len(rates))}
+	for _, r := range rates {
+		converter.rates[r.Currency] = r.Value
+	}
+	return converter, nil
+}
+
+// ConvertUSD converts a USD amount into another unit with the exchange rates in effect at the given time
+func (s *exchangeRateService) ConvertUSD(amount float64, to string, at time.Time) (*Conversion, error) {
+	converter, err := s.Converter(at)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := converter.Rate("usd", to)
+	if err != nil {
+		return nil, err
+	}
+	return &Conversion{
+		Amount:          amount,
+		From:            "usd",
+		To:              strings.ToLower(to),
+		Value:           amount * rate,
+		Rate:            rate,
+		RatesCapturedAt: converter.CapturedAt(),
+	}, nil
+}
+
+// exchangeRates converts an /exchange_rates response into rates captured at the given time, ordered by currency
+func exchangeRates(response *ExchangeRatesResponse, capturedAt time.Time) []domain.ExchangeRate {
+	rates := make([]domain.ExchangeRate, 0, len(response.Rates))
+	for currency, r := range response.Rates {
+		if currency == "" {
+			continue
+		}
+		rates = append(rates, domain.ExchangeRate{
+			CapturedAt: capturedAt,
+			Currency:   strings.ToLower(currency),
+			Name:       r.Name,
+			Unit:       r.Unit,
+			Type:       r.Type,
+			Value:      r.Value,
+		})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Currency < rates[j].Currency })
+	return rates
+}
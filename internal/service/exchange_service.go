@@ -1,37 +1,67 @@
 package service
 
 import (
+	"cgoffline/internal/domain"
 	"cgoffline/internal/repository"
 	"cgoffline/pkg/logger"
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 )
 
 // ExchangeService defines the interface for exchange operations
 type ExchangeService interface {
-	SyncExchanges() error
+	SyncExchanges(ctx context.Context) error
+	SyncExchangesData(ctx context.Context, opts ExchangesDataOptions) error
+}
+
+// ExchangesDataOptions selects the exchanges SyncExchangesData fetches details, tickers and volume charts for.
+// An exchange has to meet both thresholds; a zero threshold is not applied.
+type ExchangesDataOptions struct {
+	MinTrustScore     int
+	MinTradeVolumeBTC float64
+	Workers           int // number of concurrent workers
 }
 
 type exchangeService struct {
 	repo            repository.ExchangeRepository
+	detailRepo      repository.ExchangeDetailRepository
+	marketDataRepo  repository.CoinMarketDataRepository
+	volumeRepo      repository.ExchangeVolumeRepository
+	coinRepo        repository.CoinRepository
 	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
 }
 
 // NewExchangeService creates a new instance of ExchangeService
-func NewExchangeService(repo repository.ExchangeRepository, client *CoinGeckoClient) ExchangeService {
+func NewExchangeService(
+	repo repository.ExchangeRepository,
+	detailRepo repository.ExchangeDetailRepository,
+	marketDataRepo repository.CoinMarketDataRepository,
+	volumeRepo repository.ExchangeVolumeRepository,
+	coinRepo repository.CoinRepository,
+	client *CoinGeckoClient,
+	journal *SyncJournal,
+) ExchangeService {
 	return &exchangeService{
 		repo:            repo,
+		detailRepo:      detailRepo,
+		marketDataRepo:  marketDataRepo,
+		volumeRepo:      volumeRepo,
+		coinRepo:        coinRepo,
 		coingeckoClient: client,
+		journal:         journal,
 	}
 }
 
 // SyncExchanges fetches exchanges from CoinGecko API and stores them in the database
-func (s *exchangeService) SyncExchanges() error {
-	logger.GetLogger().Info("Starting exchanges synchronization")
+func (s *exchangeService) SyncExchanges(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindExchanges)
+	defer func() { run.finish(err) }()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
+	logger.GetLogger().Info("Starting exchanges synchronization")
 
 	// Get current exchanges in DB for logging purposes
 	currentExchanges, err := s.repo.GetAll()
@@ -56,8 +86,20 @@ func (s *exchangeService) SyncExchanges() error {
 		return fmt.Errorf("failed to store exchanges: %w", err)
 	}
 
+	run.addWritten(len(apiExchanges))
 	logger.GetLogger().WithField("count", len(apiExchanges)).Info("Successfully fetched and stored exchanges")
 
+	// Exchanges no longer listed by the API have shut down
+	listed := make([]string, len(apiExchanges))
+	for i, e := range apiExchanges {
+		listed[i] = e.CoingeckoID
+	}
+	delisted, err := markDelisted(domain.SyncKindExchanges, listed, len(currentExchanges), s.repo.MarkDelisted)
+	if err != nil {
+		logger.GetLogger().WithError(err).Error("Failed to mark delisted exchanges")
+		return fmt.Errorf("failed to mark delisted exchanges: %w", err)
+	}
+
 	// Verify count after sync
 	updatedExchanges, err := s.repo.GetAll()
 	if err != nil {
@@ -65,7 +107,287 @@ func (s *exchangeService) SyncExchanges() error {
 		return fmt.Errorf("failed to get updated exchanges: %w", err)
 	}
 	logger.GetLogger().WithField("updated_count", len(updatedExchanges)).Info("Exchanges after synchronization")
+	run.addInserted(len(updatedExchanges) - len(currentExchanges) + int(delisted))
 
 	logger.GetLogger().Info("Exchanges synchronization completed successfully")
 	return nil
 }
+
+// SyncExchangesData fetches /exchanges/{id}, every /exchanges/{id}/tickers page and the /exchanges/{id}/volume_chart
+// days missing locally for the exchanges passing the trust score and volume thresholds. An exchange that fails is
+// skipped; the others are still synced.
+func (s *exchangeService) SyncExchangesData(ctx context.Context, opts ExchangesDataOptions) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindExchangesData)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"min_trust_score":      opts.MinTrustScore,
+		"min_trade_volume_btc": opts.MinTradeVolumeBTC,
+		"workers":              opts.Workers,
+	}).Info("Starting exchanges data synchronization")
+
+	exchanges, err := s.repo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to load exchanges: %w", err)
+	}
+
+	filtered := filterExchanges(exchanges, opts)
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].CoingeckoID < filtered[j].CoingeckoID })
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"eligible": len(filtered),
+		"total":    len(exchanges),
+	}).Info("Exchanges eligible for detailed sync by trust score and volume filter")
+
+	failed := 0
+	runBounded(len(filtered), opts.Workers,
+		func(int) bool { return ctx.Err() == nil },
+		func(i int) error { return s.syncExchangeData(ctx, filtered[i]) },
+		func(i int, err error) {
+			if err != nil && ctx.Err() == nil {
+				failed++
+				logger.GetLogger().WithError(err).WithField("exchange_id", filtered[i].CoingeckoID).Warn("Failed to sync exchange data; skipping")
+				run.addSkipped(1)
+			}
+		},
+	)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("exchanges data synchronization interrupted: %w", err)
+	}
+
+	stats := s.coingeckoClient.RateLimitStats()
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"exchanges":   len(filtered),
+		"failed":      failed,
+		"api_calls":   stats.Calls,
+		"throttled":   stats.Throttled,
+		"rate_waited": stats.Waited.String(),
+	}).Info("Exchanges data synchronization completed")
+	return nil
+}
+
+// filterExchanges keeps the exchanges meeting the trust score and 24h BTC volume thresholds of opts.
+// An exchange missing the figure of an applied threshold fails it.
+func filterExchanges(exchanges []domain.Exchange, opts ExchangesDataOptions) []domain.Exchange {
+	filtered := make([]domain.Exchange, 0, len(exchanges))
+	for _, e := range exchanges {
+		if opts.MinTrustScore > 0 && (e.TrustScore == nil || *e.TrustScore < opts.MinTrustScore) {
+			continue
+		}
+		if opts.MinTradeVolumeBTC > 0 && (e.TradeVolume24hBTC == nil || *e.TradeVolume24hBTC < opts.MinTradeVolumeBTC) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// syncExchangeData stores one exchange's details, tickers and volume chart.
+// It is safe to run for several exchanges at once; an error only concerns this exchange.
+func (s *exchangeService) syncExchangeData(ctx context.Context, exchange domain.Exchange) error {
+	if err := s.storeExchangeDetail(ctx, exchange); err != nil {
+		return err
+	}
+	if err := s.storeExchangeTickers(ctx, exchange); err != nil {
+		return err
+	}
+	return s.storeExchangeVolume(ctx, exchange)
+}
+
+// storeExchangeDetail fetches /exchanges/{id} and stores it in exchange_details
+func (s *exchangeService) storeExchangeDetail(ctx context.Context, exchange domain.Exchange) error {
+	data, err := s.coingeckoClient.GetExchangeDetail(ctx, exchange.CoingeckoID)
+	if err != nil {
+		return err
+	}
+
+	raw, _ := json.Marshal(data)
+	detail := domain.ExchangeDetail{
+		ExchangeID:    exchange.ID,
+		CoingeckoID:   exchange.CoingeckoID,
+		RawJSON:       raw,
+		PublicNotice:  optionalString(data, "public_notice"),
+		AlertNotice:   optionalString(data, "alert_notice"),
+		FacebookURL:   optionalString(data, "facebook_url"),
+		RedditURL:     optionalString(data, "reddit_url"),
+		TelegramURL:   optionalString(data, "telegram_url"),
+		SlackURL:      optionalString(data, "slack_url"),
+		OtherURL1:     optionalString(data, "other_url_1"),
+		OtherURL2:     optionalString(data, "other_url_2"),
+		TwitterHandle: optionalString(data, "twitter_handle"),
+	}
+	if v, ok := data["centralized"].(bool); ok {
+		detail.Centralized = &v
+	}
+
+	if err := s.detailRepo.Upsert(detail); err != nil {
+		return err
+	}
+	syncRunFromContext(ctx).addWritten(1)
+	return nil
+}
+
+// optionalString returns a non-empty string field of a payload, or nil
+func optionalString(data map[string]any, key string) *string {
+	if v, ok := data[key].(string); ok && v != "" {
+		return &v
+	}
+	return nil
+}
+
+// storeExchangeTickers pages through /exchanges/{id}/tickers and normalizes the tickers into coin_market_data.
+// Tickers the exchange no longer lists are removed only when every page was read.
+func (s *exchangeService) storeExchangeTickers(ctx context.Context, exchange domain.Exchange) error {
+	syncStart := time.Now()
+	var tickers []TickerResponse
+	complete := true
+	for page := 1; ; page++ {
+		pageTickers, err := s.coingeckoClient.GetExchangeTickers(ctx, exchange.CoingeckoID, page)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			logger.GetLogger().WithError(err).WithFields(map[string]interface{}{"exchange_id": exchange.CoingeckoID, "page": page}).Warn("Failed to fetch exchange tickers; stopping pagination")
+			complete = false
+			break
+		}
+		tickers = append(tickers, pageTickers...)
+		if len(pageTickers) < ExchangeTickersPerPage {
+			break
+		}
+	}
+
+	coinGeckoIDs := make([]string, 0, len(tickers))
+	for _, t := range tickers {
+		coinGeckoIDs = append(coinGeckoIDs, t.CoinID)
+	}
+	coinIDs, err := s.coinRepo.GetIDsByCoingeckoIDs(coinGeckoIDs)
+	if err != nil {
+		return err
+	}
+
+	marketData := exchangeTickersToMarketData(exchange.ID, tickers, coinIDs)
+	run := syncRunFromContext(ctx)
+	run.addSkipped(len(tickers) - len(marketData))
+	if err := s.marketDataRepo.UpsertBatch(marketData); err != nil {
+		return fmt.Errorf("failed to store exchange tickers: %w", err)
+	}
+	run.addWritten(len(marketData))
+
+	if complete {
+		// Tickers last written by the coins data sync are left to that sync
+		return s.marketDataRepo.DeleteStaleByExchangeID(exchange.ID, domain.MarketDataSourceExchange, syncStart)
+	}
+	return nil
+}
+
+// exchangeTickersToMarketData converts an exchange's tickers into market data rows.
+// Tickers without a last price or of coins that are not synced are skipped.
+func exchangeTickersToMarketData(exchangeID uint, tickers []TickerResponse, coinIDs map[string]uint) []domain.CoinMarketData {
+	marketData := make([]domain.CoinMarketData, 0, len(tickers))
+	unknownCoins := 0
+
+	for _, ticker := range tickers {
+		if ticker.Last == nil {
+			continue
+		}
+		coinID, ok := coinIDs[ticker.CoinID]
+		if !ok {
+			unknownCoins++
+			continue
+		}
+
+		marketData = append(marketData, domain.CoinMarketData{
+			CoinID:                 coinID,
+			ExchangeID:             exchangeID,
+			Base:                   ticker.Base,
+			Target:                 ticker.Target,
+			Source:                 domain.MarketDataSourceExchange,
+			Price:                  ticker.Last,
+			Volume24h:              ticker.Volume,
+			ConvertedLastUSD:       ticker.ConvertedLast.USD,
+			ConvertedVolumeUSD:     ticker.ConvertedVolume.USD,
+			BidAskSpreadPercentage: ticker.BidAskSpreadPercentage,
+			TrustScore:             ticker.TrustScore,
+			IsAnomaly:              ticker.IsAnomaly,
+			IsStale:                ticker.IsStale,
+			LastUpdated:            ticker.Timestamp,
+			LastTradedAt:           ticker.LastTradedAt,
+			LastFetchAt:            ticker.LastFetchAt,
+		})
+	}
+
+	if unknownCoins > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"exchange_id": exchangeID,
+			"tickers":     unknownCoins,
+		}).Debug("Skipped tickers of coins missing from the database; sync the coin list to include them")
+	}
+
+	return marketData
+}
+
+// exchangeVolumeDays are the /volume_chart days values with daily points, ascending
+var exchangeVolumeDays = []int{30, 90, 180, 365}
+
+// storeExchangeVolume fetches the volume chart back to the newest stored day, or a year on the first sync
+func (s *exchangeService) storeExchangeVolume(ctx context.Context, exchange domain.Exchange) error {
+	since, err := s.volumeRepo.GetLatestTimestamp(exchange.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	chart, err := s.coingeckoClient.GetExchangeVolumeChart(ctx, exchange.CoingeckoID, exchangeVolumeChartDays(since, now))
+	if err != nil {
+		return err
+	}
+
+	points := exchangeVolumePoints(exchange.ID, chart, since, now)
+	if err := s.volumeRepo.CreateBatch(points); err != nil {
+		return err
+	}
+	run := syncRunFromContext(ctx)
+	run.addWritten(len(points))
+	run.addInserted(len(points))
+	return nil
+}
+
+// exchangeVolumeChartDays picks the smallest daily /volume_chart range reaching back past since
+func exchangeVolumeChartDays(since *time.Time, now time.Time) int {
+	if since != nil {
+		gap := now.Sub(*since)
+		for _, d := range exchangeVolumeDays {
+			if time.Duration(d)*24*time.Hour > gap {
+				return d
+			}
+		}
+	}
+	return exchangeVolumeDays[len(exchangeVolumeDays)-1]
+}
+
+// exchangeVolumePoints converts a volume chart into one point per completed UTC day after since.
+// The chart ends with a point for the current time, which belongs to a day that is not over yet and is left out.
+func exchangeVolumePoints(exchangeID uint, chart ExchangeVolumeChartResponse, since *time.Time, now time.Time) []domain.ExchangeVolumePoint {
+	today := now.Truncate(24 * time.Hour)
+	seen := make(map[time.Time]bool, len(chart))
+	points := make([]domain.ExchangeVolumePoint, 0, len(chart))
+	for _, entry := range chart {
+		ms, err := entry[0].Float64()
+		if err != nil {
+			continue
+		}
+		volume, err := entry[1].Float64()
+		if err != nil {
+			continue
+		}
+
+		day := time.UnixMilli(int64(ms)).UTC().Truncate(24 * time.Hour)
+		if !day.Before(today) || (since != nil && !day.After(*since)) || seen[day] {
+			continue
+		}
+		seen[day] = true
+		points = append(points, domain.ExchangeVolumePoint{ExchangeID: exchangeID, Timestamp: day, VolumeBTC: volume})
+	}
+	return points
+}
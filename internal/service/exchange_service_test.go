@@ -0,0 +1,46 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"cgoffline/internal/domain"
+)
+
+func TestFilterExchanges(t *testing.T) {
+	exchange := func(id string, trustScore *int, volume *float64) domain.Exchange {
+		return domain.Exchange{CoingeckoID: id, TrustScore: trustScore, TradeVolume24hBTC: volume}
+	}
+	score := func(v int) *int { return &v }
+	btc := func(v float64) *float64 { return &v }
+	exchanges := []domain.Exchange{
+		exchange("trusted-large", score(9), btc(5000)),
+		exchange("trusted-small", score(9), btc(10)),
+		exchange("untrusted-large", score(4), btc(5000)),
+		exchange("unscored", nil, btc(5000)),
+		exchange("no-volume", score(9), nil),
+	}
+
+	tests := []struct {
+		name string
+		opts ExchangesDataOptions
+		want []string
+	}{
+		{name: "both thresholds", opts: ExchangesDataOptions{MinTrustScore: 8, MinTradeVolumeBTC: 1000}, want: []string{"trusted-large"}},
+		{name: "trust score only", opts: ExchangesDataOptions{MinTrustScore: 8}, want: []string{"trusted-large", "trusted-small", "no-volume"}},
+		{name: "volume only", opts: ExchangesDataOptions{MinTradeVolumeBTC: 1000}, want: []string{"trusted-large", "untrusted-large", "unscored"}},
+		{name: "no thresholds", opts: ExchangesDataOptions{}, want: []string{"trusted-large", "trusted-small", "untrusted-large", "unscored", "no-volume"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range filterExchanges(exchanges, tt.opts) {
+				got = append(got, e.CoingeckoID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterExchanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GlobalService defines the interface for market-wide statistics
+type GlobalService interface {
+	SyncGlobal(ctx context.Context) error
+}
+
+type globalService struct {
+	repo            repository.GlobalSnapshotRepository
+	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
+}
+
+// NewGlobalService creates a new instance of GlobalService
+func NewGlobalService(repo repository.GlobalSnapshotRepository, client *CoinGeckoClient, journal *SyncJournal) GlobalService {
+	return &globalService{
+		repo:            repo,
+		coingeckoClient: client,
+		journal:         journal,
+	}
+}
+
+// SyncGlobal fetches /global and /global/decentralized_finance_defi and appends a snapshot of both.
+// A failed DeFi request is logged and the snapshot is stored without DeFi figures.
+func (s *globalService) SyncGlobal(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindGlobal)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting global market data synchronization")
+
+	global, err := s.coingeckoClient.GetGlobal(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := globalSnapshot(global, time.Now().UTC().Truncate(time.Second))
+
+	defi, err := s.coingeckoClient.GetGlobalDefi(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("global market data synchronization interrupted: %w", ctx.Err())
+		}
+		logger.GetLogger().WithError(err).Warn("Failed to fetch global DeFi data; storing the snapshot without it")
+		run.addSkipped(1)
+	} else {
+		applyGlobalDefi(&snapshot, defi)
+	}
+
+	if err := s.repo.Create(&snapshot); err != nil {
+		return err
+	}
+	run.addWritten(1)
+	run.addInserted(1)
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"total_market_cap_usd": snapshot.TotalMarketCapUSD,
+		"btc_dominance":        snapshot.BTCDominance,
+		"defi_market_cap":      snapshot.DefiMarketCap,
+	}).Info("Global market data synchronization completed")
+	return nil
+}
+
+// globalSnapshot converts a /global response into a snapshot captured at the given time
+func globalSnapshot(global *GlobalResponse, capturedAt time.Time) domain.GlobalSnapshot {
+	data := global.Data
+	snapshot := domain.GlobalSnapshot{
+		CapturedAt:                      capturedAt,
+		ActiveCryptocurrencies:          data.ActiveCryptocurrencies,
+		Markets:                         data.Markets,
+		TotalMarketCap:                  data.TotalMarketCap,
+		TotalVolume:                     data.TotalVolume,
+		MarketCapPercentage:             data.MarketCapPercentage,
+		TotalMarketCapUSD:               mapValue(data.TotalMarketCap, DefaultVsCurrency),
+		TotalVolumeUSD:                  mapValue(data.TotalVolume, DefaultVsCurrency),
+		BTCDominance:                    mapValue(data.MarketCapPercentage, "btc"),
+		ETHDominance:                    mapValue(data.MarketCapPercentage, "eth"),
+		MarketCapChangePercentage24hUSD: data.MarketCapChangePercentage24hUSD,
+	}
+	if data.UpdatedAt != nil {
+		t := time.Unix(*data.UpdatedAt, 0).UTC()
+		snapshot.SourceUpdatedAt = &t
+	}
+	return snapshot
+}
+
+// applyGlobalDefi copies the figures of a /global/decentralized_finance_defi response into a snapshot
+func applyGlobalDefi(snapshot *domain.GlobalSnapshot, defi *GlobalDefiResponse) {
+	data := defi.Data
+	snapshot.DefiMarketCap = numberValue(data.DefiMarketCap)
+	snapshot.EthMarketCap = numberValue(data.EthMarketCap)
+	snapshot.DefiToEthRatio = numberValue(data.DefiToEthRatio)
+	snapshot.DefiVolume24h = numberValue(data.TradingVolume24h)
+	snapshot.DefiDominance = numberValue(data.DefiDominance)
+	snapshot.TopDefiCoinName = data.TopCoinName
+	snapshot.TopDefiCoinDominance = data.TopCoinDefiDominance
+}
+
+// mapValue returns the value stored under key, or nil when it is missing
+func mapValue(m map[string]float64, key string) *float64 {
+	if v, ok := m[key]; ok {
+		return &v
+	}
+	return nil
+}
+
+// numberValue parses an optional JSON number, returning nil when it is missing or empty
+func numberValue(n *json.Number) *float64 {
+	if n == nil {
+		return nil
+	}
+	v, err := n.Float64()
+	if err != nil {
+		return nil
+	}
+	return &v
+}
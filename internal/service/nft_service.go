@@ -0,0 +1,297 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NFTsDataOptions selects the NFT collections SyncNFTsData fetches /nfts/{id} for
+type NFTsDataOptions struct {
+	// Collections whose last stored USD market cap is lower are skipped; collections never fetched are always fetched.
+	// 0 fetches every collection.
+	MinMarketCapUSD float64
+	Workers         int // number of concurrent workers
+}
+
+// NFTService defines the interface for NFT collection operations
+type NFTService interface {
+	SyncNFTs(ctx context.Context) error
+	SyncNFTsData(ctx context.Context, opts NFTsDataOptions) error
+	LookupContract(ctx context.Context, platformID, address string) (*domain.NFTCollection, error)
+}
+
+type nftService struct {
+	repo            repository.NFTCollectionRepository
+	snapshotRepo    repository.NFTSnapshotRepository
+	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
+	lookupOnline    bool
+}
+
+// NewNFTService creates a new instance of NFTService.
+// lookupOnline lets LookupContract ask CoinGecko about contracts that are not stored locally.
+func NewNFTService(
+	repo repository.NFTCollectionRepository,
+	snapshotRepo repository.NFTSnapshotRepository,
+	client *CoinGeckoClient,
+	journal *SyncJournal,
+	lookupOnline bool,
+) NFTService {
+	return &nftService{
+		repo:            repo,
+		snapshotRepo:    snapshotRepo,
+		coingeckoClient: client,
+		journal:         journal,
+		lookupOnline:    lookupOnline,
+	}
+}
+
+// SyncNFTs pages through /nfts/list and stores every listed collection in nft_collections.
+// Collections no longer listed are marked delisted.
+func (s *nftService) SyncNFTs(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindNFTs)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting NFT collections synchronization")
+
+	current, err := s.repo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	var collections []domain.NFTCollection
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		list, err := s.coingeckoClient.GetNFTList(ctx, page)
+		if err != nil {
+			return err
+		}
+		for _, e := range list {
+			if e.ID == "" || seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			collections = append(collections, domain.NFTCollection{
+				CoingeckoID:     e.ID,
+				AssetPlatformID: e.AssetPlatformID,
+				ContractAddress: e.ContractAddress,
+				Name:            e.Name,
+				Symbol:          e.Symbol,
+			})
+		}
+		if len(list) < NFTsPerPage {
+			break
+		}
+	}
+
+	if err := s.repo.UpsertListed(collections); err != nil {
+		return err
+	}
+	run.addWritten(len(collections))
+
+	listed := make([]string, len(collections))
+	for i, c := range collections {
+		listed[i] = c.CoingeckoID
+	}
+	delisted, err := markDelisted(domain.SyncKindNFTs, listed, len(current), s.repo.MarkDelisted)
+	if err != nil {
+		return fmt.Errorf("failed to mark delisted NFT collections: %w", err)
+	}
+
+	updated, err := s.repo.GetAll()
+	if err != nil {
+		return err
+	}
+	run.addInserted(len(updated) - len(current) + int(delisted))
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"collections": len(collections),
+		"delisted":    delisted,
+	}).Info("NFT collections synchronization completed")
+	return nil
+}
+
+// SyncNFTsData fetches /nfts/{id} for the collections passing the market cap threshold, refreshes their details
+// and appends a market snapshot of each. A collection that fails is skipped; the others are still synced.
+func (s *nftService) SyncNFTsData(ctx context.Context, opts NFTsDataOptions) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindNFTsData)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"min_market_cap_usd": opts.MinMarketCapUSD,
+		"workers":            opts.Workers,
+	}).Info("Starting NFT collections data synchronization")
+
+	collections, err := s.repo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	filtered := filterNFTCollections(collections, opts.MinMarketCapUSD)
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].CoingeckoID < filtered[j].CoingeckoID })
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"eligible": len(filtered),
+		"total":    len(collections),
+	}).Info("NFT collections eligible for detailed sync by market cap filter")
+
+	failed := 0
+	runBounded(len(filtered), opts.Workers,
+		func(int) bool { return ctx.Err() == nil },
+		func(i int) error {
+			payload, err := s.coingeckoClient.GetNFT(ctx, filtered[i].CoingeckoID)
+			if err != nil {
+				return err
+			}
+			_, err = s.storeNFT(payload)
+			return err
+		},
+		func(i int, err error) {
+			switch {
+			case err == nil:
+				run.addWritten(1)
+			case ctx.Err() == nil:
+				failed++
+				logger.GetLogger().WithError(err).WithField("nft_id", filtered[i].CoingeckoID).Warn("Failed to sync NFT collection data; skipping")
+				run.addSkipped(1)
+			}
+		},
+	)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("NFT collections data synchronization interrupted: %w", err)
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"collections": len(filtered),
+		"failed":      failed,
+	}).Info("NFT collections data synchronization completed")
+	return nil
+}
+
+// LookupContract finds the NFT collection deployed at a contract address. Stored collections are searched first;
+// when none matches and lookups are online, CoinGecko is asked and the collection it returns is stored.
+// Returns nil when the contract is unknown.
+func (s *nftService) LookupContract(ctx context.Context, platformID, address string) (*domain.NFTCollection, error) {
+	platformID = strings.TrimSpace(platformID)
+	address = domain.NormalizeContractAddress(address)
+	if platformID == "" || address == "" {
+		return nil, fmt.Errorf("asset platform and contract address are required")
+	}
+
+	collection, err := s.repo.GetByContract(platformID, address)
+	if err != nil || collection != nil || !s.lookupOnline {
+		return collection, err
+	}
+
+	payload, err := s.coingeckoClient.GetNFTByContract(ctx, platformID, address)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.storeNFT(payload)
+}
+
+// storeNFT stores an /nfts/{id} payload in nft_collections and appends a snapshot of its market figures
+func (s *nftService) storeNFT(payload map[string]any) (*domain.NFTCollection, error) {
+	fetchedAt := time.Now().UTC().Truncate(time.Second)
+	collection, err := nftCollectionFromPayload(payload, fetchedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpsertDetail(&collection); err != nil {
+		return nil, err
+	}
+
+	snapshot := nftSnapshotFromPayload(payload, collection.ID, fetchedAt)
+	if err := s.snapshotRepo.Create(&snapshot); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// filterNFTCollections keeps the collections never fetched and those whose stored USD market cap meets the threshold
+func filterNFTCollections(collections []domain.NFTCollection, minMarketCapUSD float64) []domain.NFTCollection {
+	filtered := make([]domain.NFTCollection, 0, len(collections))
+	for _, c := range collections {
+		if minMarketCapUSD > 0 && c.DetailFetchedAt != nil && (c.MarketCapUSD == nil || *c.MarketCapUSD < minMarketCapUSD) {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
+
+// nftCollectionFromPayload converts an /nfts/{id} payload into a collection with its details and latest figures
+func nftCollectionFromPayload(payload map[string]any, fetchedAt time.Time) (domain.NFTCollection, error) {
+	id, _ := payload["id"].(string)
+	if id == "" {
+		return domain.NFTCollection{}, fmt.Errorf("NFT collection payload without id")
+	}
+	name, _ := payload["name"].(string)
+	raw, _ := json.Marshal(payload)
+
+	collection := domain.NFTCollection{
+		CoingeckoID:          id,
+		AssetPlatformID:      optionalString(payload, "asset_platform_id"),
+		ContractAddress:      optionalString(payload, "contract_address"),
+		Name:                 name,
+		Symbol:               optionalString(payload, "symbol"),
+		RawJSON:              raw,
+		Description:          optionalString(payload, "description"),
+		NativeCurrency:       optionalString(payload, "native_currency"),
+		NativeCurrencySymbol: optionalString(payload, "native_currency_symbol"),
+		FloorPriceUSD:        nestedFloat(payload, "floor_price", "usd"),
+		MarketCapUSD:         nestedFloat(payload, "market_cap", "usd"),
+		Volume24hUSD:         nestedFloat(payload, "volume_24h", "usd"),
+		TotalSupply:          optionalFloat(payload, "total_supply"),
+		DetailFetchedAt:      &fetchedAt,
+	}
+	if image, ok := payload["image"].(map[string]any); ok {
+		collection.Image = optionalString(image, "small")
+	}
+	if owners := optionalFloat(payload, "number_of_unique_addresses"); owners != nil {
+		n := int(*owners)
+		collection.NumberOfUniqueAddresses = &n
+	}
+	return collection, nil
+}
+
+// nftSnapshotFromPayload extracts the market figures of an /nfts/{id} payload
+func nftSnapshotFromPayload(payload map[string]any, collectionID uint, capturedAt time.Time) domain.NFTSnapshot {
+	snapshot := domain.NFTSnapshot{
+		CollectionID:                     collectionID,
+		CapturedAt:                       capturedAt,
+		NativeCurrency:                   optionalString(payload, "native_currency"),
+		FloorPriceNative:                 nestedFloat(payload, "floor_price", "native_currency"),
+		FloorPriceUSD:                    nestedFloat(payload, "floor_price", "usd"),
+		MarketCapNative:                  nestedFloat(payload, "market_cap", "native_currency"),
+		MarketCapUSD:                     nestedFloat(payload, "market_cap", "usd"),
+		Volume24hNative:                  nestedFloat(payload, "volume_24h", "native_currency"),
+		Volume24hUSD:                     nestedFloat(payload, "volume_24h", "usd"),
+		FloorPriceChangePercentage24hUSD: optionalFloat(payload, "floor_price_in_usd_24h_percentage_change"),
+	}
+	if owners := optionalFloat(payload, "number_of_unique_addresses"); owners != nil {
+		n := int(*owners)
+		snapshot.NumberOfUniqueAddresses = &n
+	}
+	return snapshot
+}
+
+// nestedFloat returns a numeric field of an object nested in a decoded JSON object, or nil when either is missing
+func nestedFloat(data map[string]any, key, field string) *float64 {
+	nested, ok := data[key].(map[string]any)
+	if !ok {
+		return nil
+	}
+	return optionalFloat(nested, field)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultRetryAfter is used when CoinGecko answers 429 without a usable Retry-After header
+const defaultRetryAfter = 60 * time.Second
+
+// RateLimiter is a token bucket shared by every CoinGecko request.
+// On top of the steady rate it can be paused entirely when the API asks us to back off.
+type RateLimiter struct {
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+
+	calls     atomic.Int64
+	throttled atomic.Int64
+	waited    atomic.Int64 // nanoseconds
+}
+
+// RateLimitStats reports how the limiter has been used so far
+type RateLimitStats struct {
+	Calls     int64
+	Throttled int64
+	Waited    time.Duration
+}
+
+// NewRateLimiter creates a limiter allowing callsPerMinute requests with the given burst.
+// A non-positive callsPerMinute disables limiting.
+func NewRateLimiter(callsPerMinute int, burst int) *RateLimiter {
+	limit := rate.Inf
+	if callsPerMinute > 0 {
+		limit = rate.Limit(float64(callsPerMinute) / 60)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{limiter: rate.NewLimiter(limit, burst)}
+}
+
+// Wait blocks until a request may be sent or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		l.waited.Add(int64(time.Since(start)))
+	}()
+
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+
+	if pause > 0 {
+		if err := sleepContext(ctx, pause); err != nil {
+			return err
+		}
+	}
+
+	if err := l.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	l.calls.Add(1)
+	return nil
+}
+
+// PauseFor stops all requests for the given duration, extending any pause already in place
+func (l *RateLimiter) PauseFor(d time.Duration) {
+	l.throttled.Add(1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// Stats returns a snapshot of the limiter counters
+func (l *RateLimiter) Stats() RateLimitStats {
+	return RateLimitStats{
+		Calls:     l.calls.Load(),
+		Throttled: l.throttled.Load(),
+		Waited:    time.Duration(l.waited.Load()),
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return defaultRetryAfter
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return defaultRetryAfter
+}
+
+// sleepContext pauses for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "missing", value: "", want: defaultRetryAfter},
+		{name: "seconds", value: "30", want: 30 * time.Second},
+		{name: "zero seconds", value: "0", want: 0},
+		{name: "negative seconds", value: "-5", want: defaultRetryAfter},
+		{name: "HTTP date", value: "Tue, 14 May 2024 12:01:30 GMT", want: 90 * time.Second},
+		{name: "HTTP date in the past", value: "Tue, 14 May 2024 11:59:00 GMT", want: defaultRetryAfter},
+		{name: "garbage", value: "soon", want: defaultRetryAfter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// SyncJournal records every sync in the sync_runs table so the freshness of the offline copy can be inspected later
+type SyncJournal struct {
+	repo repository.SyncRunRepository
+}
+
+// NewSyncJournal creates a journal backed by the given repository
+func NewSyncJournal(repo repository.SyncRunRepository) *SyncJournal {
+	return &SyncJournal{repo: repo}
+}
+
+// syncRunKey is the context key carrying the current *syncRun
+type syncRunKey struct{}
+
+// syncRun accumulates the counters of one journal entry while a sync is in progress.
+// All methods are safe on a nil receiver so code running outside a journaled sync can call them freely.
+type syncRun struct {
+	journal *SyncJournal
+	run     domain.SyncRun
+
+	pages    atomic.Int64
+	calls    atomic.Int64
+	written  atomic.Int64
+	inserted atomic.Int64
+	skipped  atomic.Int64
+	partial  atomic.Bool
+}
+
+// Start opens a journal entry of the given kind and returns a context carrying it.
+// Journal write failures are logged and never fail the sync itself.
+func (j *SyncJournal) Start(ctx context.Context, kind string) (context.Context, *syncRun) {
+	r := &syncRun{
+		journal: j,
+		run: domain.SyncRun{
+			Kind:      kind,
+			Status:    domain.SyncStatusRunning,
+			StartedAt: time.Now().UTC(),
+		},
+	}
+	if j != nil {
+		if err := j.repo.Create(&r.run); err != nil {
+			logger.GetLogger().WithError(err).WithField("kind", kind).Warn("Failed to record sync run start")
+		}
+	}
+	return context.WithValue(ctx, syncRunKey{}, r), r
+}
+
+// syncRunFromContext returns the journal entry of the sync running in ctx, if any
+func syncRunFromContext(ctx context.Context) *syncRun {
+	r, _ := ctx.Value(syncRunKey{}).(*syncRun)
+	return r
+}
+
+// recordCall counts an API request and, when it succeeded, a fetched page
+func (r *syncRun) recordCall(ok bool) {
+	if r == nil {
+		return
+	}
+	r.calls.Add(1)
+	if ok {
+		r.pages.Add(1)
+	}
+}
+
+// addWritten counts rows upserted; rows not reported as inserted are journaled as updated
+func (r *syncRun) addWritten(n int) {
+	if r != nil {
+		r.written.Add(int64(n))
+	}
+}
+
+// addInserted counts how many of the written rows were new
+func (r *syncRun) addInserted(n int) {
+	if r != nil && n > 0 {
+		r.inserted.Add(int64(n))
+	}
+}
+
+// addSkipped counts records that were fetched but not stored
+func (r *syncRun) addSkipped(n int) {
+	if r != nil {
+		r.skipped.Add(int64(n))
+	}
+}
+
+// markPartial records that the sync stopped early on purpose and left work for the next run
+func (r *syncRun) markPartial() {
+	if r != nil {
+		r.partial.Store(true)
+	}
+}
+
+// finish closes the journal entry with the outcome of the sync
+func (r *syncRun) finish(err error) {
+	if r == nil {
+		return
+	}
+
+	finishedAt := time.Now().UTC()
+	r.run.FinishedAt = &finishedAt
+	r.run.PagesFetched = r.pages.Load()
+	r.run.APICalls = r.calls.Load()
+	r.run.RowsInserted = r.inserted.Load()
+	r.run.RowsUpdated = max(r.written.Load()-r.run.RowsInserted, 0)
+	r.run.RowsSkipped = r.skipped.Load()
+
+	switch {
+	case err == nil && r.partial.Load():
+		r.run.Status = domain.SyncStatusPartial
+	case err == nil:
+		r.run.Status = domain.SyncStatusSucceeded
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		r.run.Status = domain.SyncStatusInterrupted
+	default:
+		r.run.Status = domain.SyncStatusFailed
+	}
+	if err != nil {
+		msg := err.Error()
+		r.run.Error = &msg
+	}
+
+	if r.journal == nil || r.run.ID == 0 {
+		return
+	}
+	if werr := r.journal.repo.Update(&r.run); werr != nil {
+		logger.GetLogger().WithError(werr).WithField("kind", r.run.Kind).Warn("Failed to record sync run result")
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+// TrendingOptions controls what a trending sync captures besides /search/trending
+type TrendingOptions struct {
+	TopMovers  bool   // also capture /coins/top_gainers_losers, which is only served on the pro plan
+	VsCurrency string // currency of the top movers' figures
+}
+
+// TrendingService defines the interface for trending lists, search and top movers
+type TrendingService interface {
+	SyncTrending(ctx context.Context, opts TrendingOptions) error
+	Search(ctx context.Context, query string) (*SearchResponse, error)
+}
+
+type trendingService struct {
+	trendingRepo    repository.TrendingRepository
+	topMoverRepo    repository.TopMoverRepository
+	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
+}
+
+// NewTrendingService creates a new instance of TrendingService
+func NewTrendingService(
+	trendingRepo repository.TrendingRepository,
+	topMoverRepo repository.TopMoverRepository,
+	client *CoinGeckoClient,
+	journal *SyncJournal,
+) TrendingService {
+	return &trendingService{
+		trendingRepo:    trendingRepo,
+		topMoverRepo:    topMoverRepo,
+		coingeckoClient: client,
+		journal:         journal,
+	}
+}
+
+// SyncTrending appends a snapshot of the trending coins, NFTs and categories and, when enabled, of the top
+// gainers and losers. A failed top movers request is logged and the trending snapshot is kept.
+func (s *trendingService) SyncTrending(ctx context.Context, opts TrendingOptions) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindTrending)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting trending synchronization")
+	capturedAt := time.Now().UTC().Truncate(time.Second)
+
+	trending, err := s.coingeckoClient.GetTrending(ctx)
+	if err != nil {
+		return err
+	}
+	items := trendingItems(trending, capturedAt)
+	if err := s.trendingRepo.CreateBatch(items); err != nil {
+		return err
+	}
+	run.addWritten(len(items))
+	run.addInserted(len(items))
+
+	var movers []domain.TopMover
+	if opts.TopMovers {
+		response, err := s.coingeckoClient.GetTopGainersLosers(ctx, opts.VsCurrency)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("trending synchronization interrupted: %w", ctx.Err())
+			}
+			logger.GetLogger().WithError(err).Warn("Failed to fetch top gainers and losers; keeping the trending snapshot")
+			run.addSkipped(1)
+		} else {
+			movers = topMovers(response, opts.VsCurrency, capturedAt)
+			if err := s.topMoverRepo.CreateBatch(movers); err != nil {
+				return err
+			}
+			run.addWritten(len(movers))
+			run.addInserted(len(movers))
+		}
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"trending_items": len(items),
+		"top_movers":     len(movers),
+	}).Info("Trending synchronization completed")
+	return nil
+}
+
+// Search asks CoinGecko for the coins, exchanges, categories and NFTs matching a query
+func (s *trendingService) Search(ctx context.Context, query string) (*SearchResponse, error) {
+	return s.coingeckoClient.Search(ctx, query)
+}
+
+// trendingItems flattens the lists of a /search/trending response into ranked items captured at the given time
+func trendingItems(trending *TrendingResponse, capturedAt time.Time) []domain.TrendingItem {
+	items := make([]domain.TrendingItem, 0, len(trending.Coins)+len(trending.NFTs)+len(trending.Categories))
+	for i, coin := range trending.Coins {
+		items = append(items, domain.TrendingItem{
+			CapturedAt:    capturedAt,
+			Kind:          domain.TrendingKindCoin,
+			Rank:          i + 1,
+			CoingeckoID:   coin.Item.ID,
+			Symbol:        coin.Item.Symbol,
+			Name:          coin.Item.Name,
+			MarketCapRank: coin.Item.MarketCapRank,
+			PriceBTC:      coin.Item.PriceBTC,
+		})
+	}
+	for i, nft := range trending.NFTs {
+		items = append(items, domain.TrendingItem{
+			CapturedAt:  capturedAt,
+			Kind:        domain.TrendingKindNFT,
+			Rank:        i + 1,
+			CoingeckoID: nft.ID,
+			Symbol:      nft.Symbol,
+			Name:        nft.Name,
+		})
+	}
+	for i, category := range trending.Categories {
+		items = append(items, domain.TrendingItem{
+			CapturedAt:  capturedAt,
+			Kind:        domain.TrendingKindCategory,
+			Rank:        i + 1,
+			CoingeckoID: category.Slug,
+			Name:        category.Name,
+		})
+	}
+	return items
+}
+
+// topMovers converts a /coins/top_gainers_losers response into ranked entries captured at the given time
+func topMovers(response *TopGainersLosersResponse, vsCurrency string, capturedAt time.Time) []domain.TopMover {
+	movers := make([]domain.TopMover, 0, len(response.TopGainers)+len(response.TopLosers))
+	add := func(direction string, entries []map[string]any) {
+		for i, entry := range entries {
+			id, _ := entry["id"].(string)
+			if id == "" {
+				continue
+			}
+			symbol, _ := entry["symbol"].(string)
+			name, _ := entry["name"].(string)
+			mover := domain.TopMover{
+				CapturedAt:               capturedAt,
+				Direction:                direction,
+				Rank:                     i + 1,
+				CoingeckoID:              id,
+				Symbol:                   symbol,
+				Name:                     name,
+				VsCurrency:               vsCurrency,
+				Price:                    optionalFloat(entry, vsCurrency),
+				Volume24h:                optionalFloat(entry, vsCurrency+"_24h_vol"),
+				PriceChangePercentage24h: optionalFloat(entry, vsCurrency+"_24h_change"),
+			}
+			if rank := optionalFloat(entry, "market_cap_rank"); rank != nil {
+				r := int(*rank)
+				mover.MarketCapRank = &r
+			}
+			movers = append(movers, mover)
+		}
+	}
+	add(domain.TopMoverGainer, response.TopGainers)
+	add(domain.TopMoverLoser, response.TopLosers)
+	return movers
+}
+
+// optionalFloat returns a numeric field of a decoded JSON object, or nil when it is missing or null
+func optionalFloat(data map[string]any, key string) *float64 {
+	if v, ok := data[key].(float64); ok {
+		return &v
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"cgoffline/internal/domain"
+	"cgoffline/internal/repository"
+	"cgoffline/pkg/logger"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// VsCurrencyService defines the interface for supported quote currency operations
+type VsCurrencyService interface {
+	SyncSupportedVsCurrencies(ctx context.Context) error
+	ValidateVsCurrencies(ctx context.Context, codes []string) error
+}
+
+type vsCurrencyService struct {
+	repo            repository.SupportedVsCurrencyRepository
+	coingeckoClient *CoinGeckoClient
+	journal         *SyncJournal
+}
+
+// NewVsCurrencyService creates a new instance of VsCurrencyService
+func NewVsCurrencyService(repo repository.SupportedVsCurrencyRepository, client *CoinGeckoClient, journal *SyncJournal) VsCurrencyService {
+	return &vsCurrencyService{
+		repo:            repo,
+		coingeckoClient: client,
+		journal:         journal,
+	}
+}
+
+// SyncSupportedVsCurrencies replaces the stored list with CoinGecko's /simple/supported_vs_currencies
+func (s *vsCurrencyService) SyncSupportedVsCurrencies(ctx context.Context) (err error) {
+	ctx, run := s.journal.Start(ctx, domain.SyncKindVsCurrencies)
+	defer func() { run.finish(err) }()
+
+	logger.GetLogger().Info("Starting supported vs currencies synchronization")
+
+	current, err := s.repo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	codes, err := s.coingeckoClient.GetSupportedVsCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+	for i, code := range codes {
+		codes[i] = strings.ToLower(code)
+	}
+
+	if err := s.repo.ReplaceAll(codes); err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(current))
+	for _, code := range current {
+		known[code] = true
+	}
+	inserted := 0
+	for _, code := range codes {
+		if !known[code] {
+			inserted++
+		}
+	}
+	run.addWritten(len(codes))
+	run.addInserted(inserted)
+
+	logger.GetLogger().WithField("count", len(codes)).Info("Supported vs currencies synchronization completed")
+	return nil
+}
+
+// ValidateVsCurrencies rejects codes CoinGecko does not support. The supported list is synced first when none is stored;
+// if that fails too, for instance when running offline, only the default currency is let through, with a warning.
+func (s *vsCurrencyService) ValidateVsCurrencies(ctx context.Context, codes []string) error {
+	supported, err := s.repo.GetAll()
+	if err != nil {
+		return err
+	}
+	if len(supported) == 0 {
+		if err := s.SyncSupportedVsCurrencies(ctx); err != nil {
+			if len(codes) == 1 && codes[0] == DefaultVsCurrency {
+				logger.GetLogger().WithError(err).Warn("Supported vs currencies are unknown; skipping VS_CURRENCIES validation")
+				return nil
+			}
+			return fmt.Errorf("cannot validate VS_CURRENCIES, supported vs currencies are unknown: %w", err)
+		}
+		if supported, err = s.repo.GetAll(); err != nil {
+			return err
+		}
+	}
+
+	known := make(map[string]bool, len(supported))
+	for _, code := range supported {
+		known[code] = true
+	}
+	var unknown []string
+	for _, code := range codes {
+		if !known[code] {
+			unknown = append(unknown, code)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unsupported VS_CURRENCIES: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
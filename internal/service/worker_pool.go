@@ -0,0 +1,42 @@
+package service
+
+// runBounded runs work for items 0..n-1 on at most workers goroutines; workers below 1 mean 1.
+// Dispatching and collecting both happen on the calling goroutine: next is asked before each item is
+// started and returning false stops dispatching, while items already running still finish and are collected.
+// Syncs pass a next that checks the context, so a cancelled sync starts no more items but keeps what finished.
+// collect receives results in completion order, so callers can aggregate without locking.
+// API calls made by work all pass through the client's shared rate limiter, so extra workers overlap
+// request latency and database writes but never raise the call rate above the plan limit.
+func runBounded[R any](n, workers int, next func(i int) bool, work func(i int) R, collect func(i int, r R)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	type result struct {
+		i int
+		r R
+	}
+	results := make(chan result, workers)
+
+	inFlight, i := 0, 0
+	stopped := false
+	for {
+		for !stopped && i < n && inFlight < workers {
+			if !next(i) {
+				stopped = true
+				break
+			}
+			go func(i int) {
+				results <- result{i: i, r: work(i)}
+			}(i)
+			i++
+			inFlight++
+		}
+		if inFlight == 0 {
+			return
+		}
+		res := <-results
+		inFlight--
+		collect(res.i, res.r)
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBounded(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		workers int
+	}{
+		{name: "no items", n: 0, workers: 4},
+		{name: "single worker", n: 5, workers: 1},
+		{name: "workers below 1", n: 5, workers: 0},
+		{name: "more workers than items", n: 3, workers: 8},
+		{name: "fewer workers than items", n: 20, workers: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := tt.workers
+			if limit < 1 {
+				limit = 1
+			}
+
+			var running, peak atomic.Int32
+			var collected []int
+			runBounded(tt.n, tt.workers,
+				func(int) bool { return true },
+				func(i int) int {
+					cur := running.Add(1)
+					for {
+						old := peak.Load()
+						if cur <= old || peak.CompareAndSwap(old, cur) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+					running.Add(-1)
+					return i * i
+				},
+				func(i int, r int) {
+					if r != i*i {
+						t.Errorf("collect(%d) got %d, want %d", i, r, i*i)
+					}
+					collected = append(collected, i)
+				},
+			)
+
+			if len(collected) != tt.n {
+				t.Fatalf("collected %d items, want %d", len(collected), tt.n)
+			}
+			sort.Ints(collected)
+			for i, got := range collected {
+				if got != i {
+					t.Fatalf("collected items %v, want 0..%d once each", collected, tt.n-1)
+				}
+			}
+			if int(peak.Load()) > limit {
+				t.Errorf("peak concurrency %d, want at most %d", peak.Load(), limit)
+			}
+		})
+	}
+}
+
+func TestRunBoundedStop(t *testing.T) {
+	var collected []int
+	runBounded(10, 2,
+		func(i int) bool { return i < 3 },
+		func(i int) int { return i },
+		func(i int, _ int) { collected = append(collected, i) },
+	)
+	if len(collected) != 3 {
+		t.Errorf("collected %v after stopping at item 3, want items 0..2", collected)
+	}
+}
@@ -121,6 +121,303 @@ func GetMigrations() []*gormigrate.Migration {
 				return tx.Migrator().DropTable(&domain.CoinTicker{})
 			},
 		},
+		{
+			ID: "2024010109",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create coin_market_snapshots table")
+				return tx.AutoMigrate(&domain.CoinMarketSnapshot{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop coin_market_snapshots table")
+				return tx.Migrator().DropTable(&domain.CoinMarketSnapshot{})
+			},
+		},
+		{
+			ID: "2024010110",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create coin_market_chart_points table")
+				return tx.AutoMigrate(&domain.CoinMarketChartPoint{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop coin_market_chart_points table")
+				return tx.Migrator().DropTable(&domain.CoinMarketChartPoint{})
+			},
+		},
+		{
+			ID: "2024010111",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create coin_ohlc table")
+				return tx.AutoMigrate(&domain.CoinOHLC{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop coin_ohlc table")
+				return tx.Migrator().DropTable(&domain.CoinOHLC{})
+			},
+		},
+		{
+			ID: "2024010112",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Add ticker columns to coin_market_data table")
+				return tx.AutoMigrate(&domain.CoinMarketData{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop ticker columns from coin_market_data table")
+				tx.Exec("DROP INDEX IF EXISTS idx_coin_market_data_ticker")
+				tx.Exec("DROP INDEX IF EXISTS idx_coin_market_data_pair")
+				for _, column := range []string{
+					"base", "target", "converted_last_usd", "converted_volume_usd", "bid_ask_spread_percentage",
+					"trust_score", "is_anomaly", "is_stale", "last_traded_at", "last_fetch_at",
+				} {
+					if err := tx.Exec("ALTER TABLE coin_market_data DROP COLUMN IF EXISTS " + column).Error; err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "2024010113",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create sync_runs table")
+				return tx.AutoMigrate(&domain.SyncRun{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop sync_runs table")
+				return tx.Migrator().DropTable(&domain.SyncRun{})
+			},
+		},
+		{
+			ID: "2024010114",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create sync_checkpoints table")
+				return tx.AutoMigrate(&domain.SyncCheckpoint{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop sync_checkpoints table")
+				return tx.Migrator().DropTable(&domain.SyncCheckpoint{})
+			},
+		},
+		{
+			ID: "2024010115",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create coin_quotes and supported_vs_currencies tables")
+				return tx.AutoMigrate(&domain.CoinQuote{}, &domain.SupportedVsCurrency{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop coin_quotes and supported_vs_currencies tables")
+				return tx.Migrator().DropTable(&domain.CoinQuote{}, &domain.SupportedVsCurrency{})
+			},
+		},
+		{
+			ID: "2024010116",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create coin_category_memberships table")
+				if err := tx.AutoMigrate(&domain.CoinCategoryMembership{}); err != nil {
+					return err
+				}
+				// Link the coins whose details are already stored instead of waiting for the next coins data sync
+				return tx.Exec(`
+					INSERT INTO coin_category_memberships (coin_id, category_id, created_at)
+					SELECT d.coin_id, c.id, NOW()
+					FROM coin_details d
+					CROSS JOIN LATERAL jsonb_array_elements_text(d.categories) AS n(name)
+					JOIN coin_categories c ON lower(c.name) = lower(n.name) AND c.deleted_at IS NULL
+					WHERE d.deleted_at IS NULL AND jsonb_typeof(d.categories) = 'array'
+					ON CONFLICT DO NOTHING
+				`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop coin_category_memberships table")
+				return tx.Migrator().DropTable(&domain.CoinCategoryMembership{})
+			},
+		},
+		{
+			ID: "2024010117",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create coin_contracts table")
+				if err := tx.AutoMigrate(&domain.CoinContract{}); err != nil {
+					return err
+				}
+				// Extract the contracts of the coins whose details are already stored; hex addresses are lowercased
+				// the same way as domain.NormalizeContractAddress does
+				return tx.Exec(`
+					INSERT INTO coin_contracts (coin_id, asset_platform_id, contract_address, decimal_place, created_at, updated_at)
+					SELECT d.coin_id, p.key,
+						CASE WHEN lower(btrim(p.value)) LIKE '0x%' THEN lower(btrim(p.value)) ELSE btrim(p.value) END,
+						CASE WHEN jsonb_typeof(d.raw_json->'detail_platforms'->p.key->'decimal_place') = 'number'
+							THEN (d.raw_json->'detail_platforms'->p.key->>'decimal_place')::integer END,
+						NOW(), NOW()
+					FROM coin_details d
+					CROSS JOIN LATERAL jsonb_each_text(d.raw_json->'platforms') AS p(key, value)
+					WHERE d.deleted_at IS NULL AND jsonb_typeof(d.raw_json->'platforms') = 'object'
+						AND p.key <> '' AND btrim(coalesce(p.value, '')) <> ''
+					ON CONFLICT DO NOTHING
+				`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop coin_contracts table")
+				return tx.Migrator().DropTable(&domain.CoinContract{})
+			},
+		},
+		{
+			ID: "2024010118",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create contract_lookups table")
+				return tx.AutoMigrate(&domain.ContractLookup{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop contract_lookups table")
+				return tx.Migrator().DropTable(&domain.ContractLookup{})
+			},
+		},
+		{
+			ID: "2024010119",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Add active column to coins table")
+
+				// Existing coins all came from /coins/markets, which only lists active coins. The default is set
+				// in SQL rather than on the model so GORM does not turn an explicit false into true.
+				if err := tx.Exec("ALTER TABLE coins ADD COLUMN IF NOT EXISTS active BOOLEAN NOT NULL DEFAULT true").Error; err != nil {
+					return err
+				}
+				if err := tx.Exec("ALTER TABLE coins ALTER COLUMN active SET DEFAULT true").Error; err != nil {
+					return err
+				}
+				return tx.Exec("CREATE INDEX IF NOT EXISTS idx_coins_active ON coins(active)").Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop active column from coins table")
+				tx.Exec("DROP INDEX IF EXISTS idx_coins_active")
+				return tx.Exec("ALTER TABLE coins DROP COLUMN IF EXISTS active").Error
+			},
+		},
+		{
+			ID: "2024010120",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create exchange_details and exchange_volume_points tables")
+				return tx.AutoMigrate(&domain.ExchangeDetail{}, &domain.ExchangeVolumePoint{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop exchange_details and exchange_volume_points tables")
+				return tx.Migrator().DropTable(&domain.ExchangeDetail{}, &domain.ExchangeVolumePoint{})
+			},
+		},
+		{
+			ID: "2024010121",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create global_snapshots table")
+				return tx.AutoMigrate(&domain.GlobalSnapshot{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop global_snapshots table")
+				return tx.Migrator().DropTable(&domain.GlobalSnapshot{})
+			},
+		},
+		{
+			ID: "2024010122",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create trending_items and top_movers tables")
+				return tx.AutoMigrate(&domain.TrendingItem{}, &domain.TopMover{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop trending_items and top_movers tables")
+				return tx.Migrator().DropTable(&domain.TrendingItem{}, &domain.TopMover{})
+			},
+		},
+		{
+			ID: "2024010123",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create nft_collections and nft_snapshots tables")
+				return tx.AutoMigrate(&domain.NFTCollection{}, &domain.NFTSnapshot{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop nft_collections and nft_snapshots tables")
+				return tx.Migrator().DropTable(&domain.NFTCollection{}, &domain.NFTSnapshot{})
+			},
+		},
+		{
+			ID: "2024010124",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create derivatives_exchanges, derivative_contracts and derivative_snapshots tables")
+				return tx.AutoMigrate(&domain.DerivativesExchange{}, &domain.DerivativeContract{}, &domain.DerivativeSnapshot{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop derivatives_exchanges, derivative_contracts and derivative_snapshots tables")
+				return tx.Migrator().DropTable(&domain.DerivativesExchange{}, &domain.DerivativeContract{}, &domain.DerivativeSnapshot{})
+			},
+		},
+		{
+			ID: "2024010125",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create exchange_rates table")
+				return tx.AutoMigrate(&domain.ExchangeRate{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop exchange_rates table")
+				return tx.Migrator().DropTable(&domain.ExchangeRate{})
+			},
+		},
+		{
+			ID: "2024010126",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Add market columns to coin_categories and create category_snapshots table")
+				return tx.AutoMigrate(&domain.CoinCategory{}, &domain.CategorySnapshot{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop category_snapshots table and market columns from coin_categories")
+				if err := tx.Migrator().DropTable(&domain.CategorySnapshot{}); err != nil {
+					return err
+				}
+				for _, column := range []string{
+					"content", "market_cap", "market_cap_change_24h", "volume_24h", "top_3_coin_ids", "top_3_coins",
+					"market_data_updated_at",
+				} {
+					if err := tx.Exec("ALTER TABLE coin_categories DROP COLUMN IF EXISTS " + column).Error; err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "2024010127",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Create coin_market_chart_coverage table")
+				return tx.AutoMigrate(&domain.CoinMarketChartCoverage{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop coin_market_chart_coverage table")
+				return tx.Migrator().DropTable(&domain.CoinMarketChartCoverage{})
+			},
+		},
+		{
+			ID: "2024010128",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Add roi column to coins table")
+				return tx.Exec("ALTER TABLE coins ADD COLUMN IF NOT EXISTS roi JSONB").Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop roi column from coins table")
+				return tx.Exec("ALTER TABLE coins DROP COLUMN IF EXISTS roi").Error
+			},
+		},
+		{
+			ID: "2024010129",
+			Migrate: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Running migration: Add source column to coin_market_data table")
+
+				// Tickers stored so far are attributed to the coins data sync, which wrote them first
+				if err := tx.Exec("ALTER TABLE coin_market_data ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'coin'").Error; err != nil {
+					return err
+				}
+				return tx.Exec("CREATE INDEX IF NOT EXISTS idx_coin_market_data_source ON coin_market_data(source)").Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				logger.GetLogger().Info("Rolling back migration: Drop source column from coin_market_data table")
+				tx.Exec("DROP INDEX IF EXISTS idx_coin_market_data_source")
+				return tx.Exec("ALTER TABLE coin_market_data DROP COLUMN IF EXISTS source").Error
+			},
+		},
 	}
 }
 
@@ -4,15 +4,17 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all configuration for our application
 type Config struct {
-	Database DatabaseConfig
-	API      APIConfig
-	Server   ServerConfig
-	Logging  LoggingConfig
+	Database  DatabaseConfig
+	API       APIConfig
+	Server    ServerConfig
+	Scheduler SchedulerConfig
+	Logging   LoggingConfig
 }
 
 // DatabaseConfig holds database connection configuration
@@ -26,19 +28,77 @@ type DatabaseConfig struct {
 	TimeZone string
 }
 
+// CoinGecko API plans
+const (
+	PlanPublic = "public"
+	PlanDemo   = "demo"
+	PlanPro    = "pro"
+)
+
+// CoinGecko API base URLs
+const (
+	PublicCoinGeckoBaseURL = "https://api.coingecko.com/api/v3"
+	ProCoinGeckoBaseURL    = "https://pro-api.coingecko.com/api/v3"
+)
+
 // APIConfig holds external API configuration
 type APIConfig struct {
 	CoinGeckoBaseURL string
+	APIKey           string
+	Plan             string
 	Timeout          time.Duration
 	RetryAttempts    int
 	RetryDelay       time.Duration
 	MinTotalVolume   float64
+	CallsPerMinute   int
+	RateLimitBurst   int
+	OHLCInterval     string
+	// Coins data sync: skip coins fetched within CoinsDataFreshness and stop after CoinsDataMaxDuration (0 = no limit)
+	CoinsDataFreshness   time.Duration
+	CoinsDataMaxDuration time.Duration
+	// SyncWorkers is how many coins per-coin syncs fetch concurrently; all of them share the rate limit
+	SyncWorkers int
+	// VsCurrencies are the quote currencies kept in coin_quotes, lowercase
+	VsCurrencies []string
+	// Exchanges data sync: only exchanges with at least this trust score and 24h BTC volume (0 = no limit)
+	ExchangesMinTrustScore int
+	ExchangesMinVolumeBTC  float64
+	// NFT collections data sync: collections fetched before with a lower USD market cap are skipped (0 = no limit)
+	NFTsMinMarketCapUSD float64
+	// Contract lookups ask CoinGecko about addresses unknown locally when ContractLookupOnline is set,
+	// and do not ask again about an address it did not list for ContractLookupMissTTL
+	ContractLookupOnline  bool
+	ContractLookupMissTTL time.Duration
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port int
-	Host string
+	Port            int
+	Host            string
+	CoinGeckoCompat bool
+}
+
+// SchedulerConfig holds the schedules used by daemon mode.
+// Each value is a cron expression, a descriptor like "@every 1h", a Go duration, or "off".
+type SchedulerConfig struct {
+	RunOnStart           bool
+	AssetPlatforms       string
+	CoinCategories       string
+	CoinCategoriesMarket string
+	Exchanges            string
+	ExchangesData        string
+	Coins                string
+	CoinList             string
+	CoinsData            string
+	OHLC                 string
+	VsCurrencies         string
+	Global               string
+	Trending             string
+	NFTs                 string
+	NFTsData             string
+	Derivatives          string
+	DerivativesExchanges string
+	ExchangeRates        string
 }
 
 // LoggingConfig holds logging configuration
@@ -49,6 +109,9 @@ type LoggingConfig struct {
 
 // LoadConfig loads configuration from environment variables with defaults
 func LoadConfig() *Config {
+	plan := strings.ToLower(getEnv("COINGECKO_API_PLAN", PlanPublic))
+	ohlcInterval := strings.ToLower(getEnv("OHLC_INTERVAL", "4h"))
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -60,15 +123,50 @@ func LoadConfig() *Config {
 			TimeZone: getEnv("DB_TIMEZONE", "UTC"),
 		},
 		API: APIConfig{
-			CoinGeckoBaseURL: getEnv("COINGECKO_BASE_URL", "https://api.coingecko.com/api/v3"),
-			Timeout:          getEnvAsDuration("API_TIMEOUT", 30*time.Second),
-			RetryAttempts:    getEnvAsInt("API_RETRY_ATTEMPTS", 3),
-			RetryDelay:       getEnvAsDuration("API_RETRY_DELAY", 1*time.Second),
-			MinTotalVolume:   getEnvAsFloat("COINS_MIN_TOTAL_VOLUME", 1000000),
+			CoinGeckoBaseURL:       getEnv("COINGECKO_BASE_URL", PublicCoinGeckoBaseURL),
+			APIKey:                 getEnv("COINGECKO_API_KEY", ""),
+			Plan:                   plan,
+			Timeout:                getEnvAsDuration("API_TIMEOUT", 30*time.Second),
+			RetryAttempts:          getEnvAsInt("API_RETRY_ATTEMPTS", 3),
+			RetryDelay:             getEnvAsDuration("API_RETRY_DELAY", 1*time.Second),
+			MinTotalVolume:         getEnvAsFloat("COINS_MIN_TOTAL_VOLUME", 1000000),
+			CallsPerMinute:         getEnvAsInt("API_CALLS_PER_MINUTE", defaultCallsPerMinute(plan)),
+			RateLimitBurst:         getEnvAsInt("API_RATE_LIMIT_BURST", 1),
+			OHLCInterval:           ohlcInterval,
+			CoinsDataFreshness:     getEnvAsDuration("COINS_DATA_FRESHNESS", 24*time.Hour),
+			CoinsDataMaxDuration:   getEnvAsDuration("COINS_DATA_MAX_DURATION", 0),
+			SyncWorkers:            getEnvAsInt("SYNC_WORKERS", 1),
+			VsCurrencies:           getEnvAsList("VS_CURRENCIES", []string{"usd"}),
+			ExchangesMinTrustScore: getEnvAsInt("EXCHANGES_MIN_TRUST_SCORE", 8),
+			ExchangesMinVolumeBTC:  getEnvAsFloat("EXCHANGES_MIN_VOLUME_BTC", 1000),
+			NFTsMinMarketCapUSD:    getEnvAsFloat("NFTS_MIN_MARKET_CAP_USD", 1000000),
+			ContractLookupOnline:   getEnvAsBool("CONTRACT_LOOKUP_ONLINE", true),
+			ContractLookupMissTTL:  getEnvAsDuration("CONTRACT_LOOKUP_MISS_TTL", 24*time.Hour),
 		},
 		Server: ServerConfig{
-			Port: getEnvAsInt("SERVER_PORT", 8080),
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:            getEnvAsInt("SERVER_PORT", 8080),
+			Host:            getEnv("SERVER_HOST", "0.0.0.0"),
+			CoinGeckoCompat: getEnvAsBool("SERVER_COINGECKO_COMPAT", true),
+		},
+		Scheduler: SchedulerConfig{
+			RunOnStart:           getEnvAsBool("SCHEDULE_RUN_ON_START", true),
+			AssetPlatforms:       getEnv("SCHEDULE_ASSET_PLATFORMS", "24h"),
+			CoinCategories:       getEnv("SCHEDULE_COIN_CATEGORIES", "24h"),
+			CoinCategoriesMarket: getEnv("SCHEDULE_COIN_CATEGORIES_MARKET", "1h"),
+			Exchanges:            getEnv("SCHEDULE_EXCHANGES", "6h"),
+			ExchangesData:        getEnv("SCHEDULE_EXCHANGES_DATA", "0 4 * * *"),
+			Coins:                getEnv("SCHEDULE_COINS", "15m"),
+			CoinList:             getEnv("SCHEDULE_COIN_LIST", "24h"),
+			CoinsData:            getEnv("SCHEDULE_COINS_DATA", "0 3 * * *"),
+			OHLC:                 getEnv("SCHEDULE_OHLC", defaultOHLCSchedule(ohlcInterval)),
+			VsCurrencies:         getEnv("SCHEDULE_VS_CURRENCIES", "24h"),
+			Global:               getEnv("SCHEDULE_GLOBAL", "1h"),
+			Trending:             getEnv("SCHEDULE_TRENDING", "1h"),
+			NFTs:                 getEnv("SCHEDULE_NFTS", "24h"),
+			NFTsData:             getEnv("SCHEDULE_NFTS_DATA", "0 5 * * *"),
+			Derivatives:          getEnv("SCHEDULE_DERIVATIVES", "1h"),
+			DerivativesExchanges: getEnv("SCHEDULE_DERIVATIVES_EXCHANGES", "24h"),
+			ExchangeRates:        getEnv("SCHEDULE_EXCHANGE_RATES", "1h"),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -77,6 +175,68 @@ func LoadConfig() *Config {
 	}
 }
 
+// Validate checks configuration values that cannot be defaulted safely
+func (c *Config) Validate() error {
+	switch c.API.Plan {
+	case PlanPublic:
+	case PlanDemo, PlanPro:
+		if c.API.APIKey == "" {
+			return fmt.Errorf("COINGECKO_API_KEY is required for the %s plan", c.API.Plan)
+		}
+	default:
+		return fmt.Errorf("invalid COINGECKO_API_PLAN %q (must be %s, %s or %s)", c.API.Plan, PlanPublic, PlanDemo, PlanPro)
+	}
+
+	if len(c.API.VsCurrencies) == 0 {
+		return fmt.Errorf("VS_CURRENCIES must list at least one currency")
+	}
+
+	if c.API.SyncWorkers < 1 {
+		return fmt.Errorf("invalid SYNC_WORKERS %d (must be at least 1)", c.API.SyncWorkers)
+	}
+
+	switch c.API.OHLCInterval {
+	case "30m", "4h", "4d":
+	case "hourly", "daily":
+		// Explicit intervals are served by /coins/{id}/ohlc/range, which needs a paid plan
+		if c.API.Plan != PlanPro {
+			return fmt.Errorf("OHLC_INTERVAL %q requires the %s plan", c.API.OHLCInterval, PlanPro)
+		}
+	default:
+		return fmt.Errorf("invalid OHLC_INTERVAL %q (must be 30m, 4h, 4d, hourly or daily)", c.API.OHLCInterval)
+	}
+	return nil
+}
+
+// defaultCallsPerMinute matches the client-side rate limit to the plan's published limit
+func defaultCallsPerMinute(plan string) int {
+	switch plan {
+	case PlanDemo:
+		return 30
+	case PlanPro:
+		return 500
+	}
+	return 10
+}
+
+// defaultOHLCSchedule runs the OHLC sync once per candle of the configured interval; more often only finds
+// candles that are still forming
+func defaultOHLCSchedule(interval string) string {
+	switch interval {
+	case "30m":
+		return "30m"
+	case "4h":
+		return "4h"
+	case "4d":
+		return "96h"
+	case "hourly":
+		return "1h"
+	case "daily":
+		return "24h"
+	}
+	return "1h"
+}
+
 // GetDSN returns the database connection string
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
@@ -127,3 +287,28 @@ func getEnvAsFloat(key string, defaultValue float64) float64 {
 	}
 	return defaultValue
 }
+
+// getEnvAsList gets a comma-separated environment variable as a lowercase list with a fallback default value
+func getEnvAsList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvAsBool gets an environment variable as bool with a fallback default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}